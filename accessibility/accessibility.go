@@ -4,33 +4,179 @@ import (
 	"bufio"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
 	"strconv"
 	"strings"
 )
 
-// PromptInt prompts a user for an integer between a certain range.
+// Prompter prompts a user for values, reading answers from a reader and
+// writing prompts to a writer.
 //
-// Given invalid input (non-integers, integers outside of the range), the user
-// will continue to be reprompted until a valid input is given, ensuring that
-// the return value is always valid.
-func PromptInt(prompt string, low, high int) int {
+// Answers are read one byte at a time so that several prompters, or other
+// readers, can take turns reading from the same stream without losing input.
+// Pass a *bufio.Reader to read buffered instead.
+type Prompter struct {
+	r io.Reader
+	w io.Writer
+}
+
+// NewPrompter returns a prompter reading from r and writing to w.
+func NewPrompter(r io.Reader, w io.Writer) *Prompter {
+	return &Prompter{r: r, w: w}
+}
+
+// readLine reads a line of input without its line ending. It returns io.EOF
+// once the input is exhausted.
+func (p *Prompter) readLine() (string, error) {
+	if br, ok := p.r.(*bufio.Reader); ok {
+		line, err := br.ReadString('\n')
+		if err == io.EOF && line != "" {
+			err = nil
+		}
+		return strings.TrimRight(line, "\r\n"), err
+	}
+
 	var (
-		input  string
-		choice int
+		line strings.Builder
+		b    [1]byte
 	)
+	for {
+		n, err := p.r.Read(b[:])
+		if n > 0 {
+			if b[0] == '\n' {
+				break
+			}
+			line.WriteByte(b[0])
+		}
+		if err == io.EOF && line.Len() > 0 {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return strings.TrimRight(line.String(), "\r"), nil
+}
+
+// PromptString prompts a user for a string value and validates it against a
+// validator function. It re-prompts the user, saying why the input was
+// rejected, until a valid input is given, and returns an error if the input
+// ends first.
+func (p *Prompter) PromptString(prompt string, validator func(input string) error) (string, error) {
+	for {
+		fmt.Fprint(p.w, prompt)
+		input, err := p.readLine()
+		if err != nil {
+			return "", fmt.Errorf("reading input: %w", err)
+		}
+
+		if err := validator(input); err != nil {
+			fmt.Fprintf(p.w, "Invalid input: %v\n", err)
+			continue
+		}
+
+		return input, nil
+	}
+}
 
+// PromptInt prompts a user for an integer between a certain range.
+//
+// Given invalid input (non-integers, integers outside of the range), the user
+// will continue to be reprompted until a valid input is given.
+func (p *Prompter) PromptInt(prompt string, low, high int) (int, error) {
 	validInt := func(s string) error {
 		i, err := strconv.Atoi(s)
 		if err != nil || i < low || i > high {
-			return errors.New("invalid input. please try again")
+			return fmt.Errorf("enter a whole number from %d to %d", low, high)
 		}
 		return nil
 	}
 
-	input = PromptString(prompt, validInt)
-	choice, _ = strconv.Atoi(input)
-	return choice
+	input, err := p.PromptString(prompt, validInt)
+	if err != nil {
+		return 0, err
+	}
+	choice, _ := strconv.Atoi(input)
+	return choice, nil
+}
+
+// PromptFloat prompts a user for a number between a certain range.
+//
+// Given invalid input (non-numbers, numbers outside of the range), the user
+// will continue to be reprompted until a valid input is given.
+func (p *Prompter) PromptFloat(prompt string, low, high float64) (float64, error) {
+	parse := func(s string) (float64, error) {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, rangeError(low, high)
+		}
+		return f, nil
+	}
+	return p.PromptNumber(prompt, low, high, parse)
+}
+
+// PromptNumber prompts a user for a number between a certain range, parsed
+// with parse, such as to accept numbers written with other separators. Use
+// infinite bounds for a range open on either side.
+//
+// Given invalid input (rejected by parse, numbers outside of the range), the
+// user will continue to be reprompted until a valid input is given. The
+// errors of parse are shown to the user.
+func (p *Prompter) PromptNumber(prompt string, low, high float64, parse func(string) (float64, error)) (float64, error) {
+	var choice float64
+	validNumber := func(s string) error {
+		f, err := parse(s)
+		if err != nil {
+			return err
+		}
+		if math.IsNaN(f) || f < low || f > high {
+			return rangeError(low, high)
+		}
+		choice = f
+		return nil
+	}
+
+	if _, err := p.PromptString(prompt, validNumber); err != nil {
+		return 0, err
+	}
+	return choice, nil
+}
+
+// rangeError returns the error of a number outside of the range from low to
+// high, either of which may be infinite.
+func rangeError(low, high float64) error {
+	format := func(f float64) string {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	switch {
+	case !math.IsInf(low, 0) && !math.IsInf(high, 0):
+		return fmt.Errorf("enter a number from %s to %s", format(low), format(high))
+	case !math.IsInf(low, 0):
+		return fmt.Errorf("enter a number of at least %s", format(low))
+	case !math.IsInf(high, 0):
+		return fmt.Errorf("enter a number of at most %s", format(high))
+	}
+	return errors.New("enter a number")
+}
+
+// PromptBool prompts a user for a boolean value.
+//
+// Given invalid input (non-boolean), the user will continue to be reprompted
+// until a valid input is given.
+func (p *Prompter) PromptBool() (bool, error) {
+	validBool := func(s string) error {
+		_, err := parseBool(s)
+		return err
+	}
+
+	input, err := p.PromptString("Choose [y/N]: ", validBool)
+	if err != nil {
+		return false, err
+	}
+	b, _ := parseBool(input)
+	return b, nil
 }
 
 func parseBool(s string) (bool, error) {
@@ -48,47 +194,91 @@ func parseBool(s string) (bool, error) {
 		}
 	}
 
-	return false, errors.New("invalid input. please try again")
+	return false, errors.New("enter y or n")
 }
 
-// PromptBool prompts a user for a boolean value.
+// errInvalid is the error shown by the package level functions, which keep
+// the message they always had.
+var errInvalid = errors.New("invalid input. please try again")
+
+// PromptInt prompts a user for an integer between a certain range on the
+// standard input and output.
+//
+// Given invalid input (non-integers, integers outside of the range), the user
+// will continue to be reprompted until a valid input is given, ensuring that
+// the return value is always valid. It panics if the input ends first, like
+// PromptString.
+func PromptInt(prompt string, low, high int) int {
+	validInt := func(s string) error {
+		i, err := strconv.Atoi(s)
+		if err != nil || i < low || i > high {
+			return errInvalid
+		}
+		return nil
+	}
+
+	choice, _ := strconv.Atoi(PromptString(prompt, validInt))
+	return choice
+}
+
+// PromptFloat prompts a user for a number between a certain range on the
+// standard input and output.
+//
+// Given invalid input (non-numbers, numbers outside of the range), the user
+// will continue to be reprompted until a valid input is given, ensuring that
+// the return value is always valid. It panics if the input ends first, like
+// PromptString.
+func PromptFloat(prompt string, low, high float64) float64 {
+	validFloat := func(s string) error {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil || f < low || f > high {
+			return errInvalid
+		}
+		return nil
+	}
+
+	choice, _ := strconv.ParseFloat(PromptString(prompt, validFloat), 64)
+	return choice
+}
+
+// PromptBool prompts a user for a boolean value on the standard input and
+// output.
 //
 // Given invalid input (non-boolean), the user will continue to be reprompted
 // until a valid input is given, ensuring that the return value is always valid.
+// It panics if the input ends first, like PromptString.
 func PromptBool() bool {
 	validBool := func(s string) error {
-		_, err := parseBool(s)
-		return err
+		if _, err := parseBool(s); err != nil {
+			return errInvalid
+		}
+		return nil
 	}
 
-	input := PromptString("Choose [y/N]: ", validBool)
-	b, _ := parseBool(input)
+	b, _ := parseBool(PromptString("Choose [y/N]: ", validBool))
 	return b
 }
 
-// PromptString prompts a user for a string value and validates it against a
-// validator function. It re-prompts the user until a valid input is given.
+// PromptString prompts a user for a string value on the standard input and
+// output, and validates it against a validator function. It re-prompts the
+// user until a valid input is given.
+//
+// Since there is no valid input to return once the standard input ends, it
+// panics then. Use a Prompter to get an error instead.
 func PromptString(prompt string, validator func(input string) error) string {
-	scanner := bufio.NewScanner(os.Stdin)
-
-	var (
-		valid bool
-		input string
-	)
-
-	for !valid {
-		fmt.Print(prompt)
-		_ = scanner.Scan()
-		input = scanner.Text()
-
-		err := validator(input)
+	p := NewPrompter(os.Stdin, os.Stdout)
+	for {
+		fmt.Fprint(p.w, prompt)
+		input, err := p.readLine()
 		if err != nil {
-			fmt.Println(err)
+			panic(fmt.Errorf("accessibility: reading input: %w", err))
+		}
+
+		if err := validator(input); err != nil {
+			fmt.Fprintln(p.w, err)
 			continue
 		}
 
-		break
+		return input
 	}
-
-	return input
 }
@@ -0,0 +1,119 @@
+package huh
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/thedeveloper-sharath/huh/accessibility"
+)
+
+// accessibleRenderer announces fields and prompts for answers in accessible
+// mode.
+//
+// Everything is written as plain text, without escape codes, so that screen
+// readers only read out what matters. Every field is announced the same way:
+// its title, description and rules, then a prompt showing the current value
+// in brackets, which is kept when the answer is left empty.
+type accessibleRenderer struct {
+	w        io.Writer
+	prompter *accessibility.Prompter
+}
+
+// newAccessibleRenderer returns a renderer reading answers from r and writing
+// to w.
+func newAccessibleRenderer(w io.Writer, r io.Reader) *accessibleRenderer {
+	return &accessibleRenderer{w: w, prompter: accessibility.NewPrompter(r, w)}
+}
+
+// accessibleRunner is implemented by fields that run in accessible mode on
+// the given streams, reading answers from r and writing prompts to w.
+type accessibleRunner interface {
+	RunAccessible(w io.Writer, r io.Reader) error
+}
+
+// runAccessible runs a field in accessible mode. Fields that don't implement
+// accessibleRunner are run with Run, on the standard streams.
+func runAccessible(field Field, w io.Writer, r io.Reader) error {
+	field = field.WithAccessible(true)
+	if runner, ok := field.(accessibleRunner); ok {
+		return runner.RunAccessible(w, r)
+	}
+	return field.Run()
+}
+
+// plain strips styles from text written by the user.
+func plain(s string) string {
+	return strings.TrimSpace(ansi.Strip(s))
+}
+
+// announce writes the title and description of a field, followed by the
+// rules its answer must follow.
+func (a *accessibleRenderer) announce(title, description string, rules ...string) {
+	for _, line := range append([]string{title, description}, rules...) {
+		if line = plain(line); line != "" {
+			fmt.Fprintln(a.w, line)
+		}
+	}
+}
+
+// prompt prompts for an answer until it passes validation. The current value
+// is shown in brackets, and is the answer if the input is left empty.
+func (a *accessibleRenderer) prompt(label, current string, validate func(string) error) (string, error) {
+	orCurrent := func(s string) string {
+		if s == "" {
+			return current
+		}
+		return s
+	}
+
+	input, err := a.prompter.PromptString(promptLabel(label, current), func(s string) error {
+		return validate(orCurrent(s))
+	})
+	if err != nil {
+		return "", err
+	}
+	return orCurrent(input), nil
+}
+
+// promptNumber prompts for a number between low and high, parsed with parse,
+// showing the current value like prompt.
+func (a *accessibleRenderer) promptNumber(label, current string, low, high float64, parse func(string) (float64, error)) (float64, error) {
+	return a.prompter.PromptNumber(promptLabel(label, current), low, high, func(s string) (float64, error) {
+		if s == "" {
+			s = current
+		}
+		return parse(s)
+	})
+}
+
+// promptLabel returns the prompt of an answer, showing the current value in
+// brackets.
+func promptLabel(label, current string) string {
+	if current != "" {
+		label += " [" + current + "]"
+	}
+	return label + ": "
+}
+
+// reject says why an answer was rejected.
+func (a *accessibleRenderer) reject(err error) {
+	fmt.Fprintf(a.w, "Invalid input: %v\n", err)
+}
+
+// answer confirms the answer given to the field.
+func (a *accessibleRenderer) answer(answer string) {
+	fmt.Fprintf(a.w, "Answer: %s\n\n", plain(answer))
+}
+
+// validChoice validates the number of a choice between low and high.
+func validChoice(low, high int) func(string) error {
+	return func(s string) error {
+		if i, err := strconv.Atoi(s); err != nil || i < low || i > high {
+			return fmt.Errorf("enter a number from %d to %d", low, high)
+		}
+		return nil
+	}
+}
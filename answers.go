@@ -0,0 +1,318 @@
+package huh
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// envAnswerPrefix is the prefix of environment variables read by
+// Form.WithEnvAnswers.
+const envAnswerPrefix = "HUH_"
+
+// AnswersError is the error returned when prefilled answers can't complete a
+// form, either because some answers are invalid or because the form is not
+// interactive and some answers are missing.
+type AnswersError struct {
+	// Missing are the keys of the fields without an answer.
+	Missing []string
+
+	// Invalid are the validation errors of the fields with a rejected
+	// answer, by key.
+	Invalid map[string]error
+}
+
+// Error implements error.
+func (e *AnswersError) Error() string {
+	var parts []string
+	if len(e.Missing) > 0 {
+		parts = append(parts, "missing answers for "+strings.Join(e.Missing, ", "))
+	}
+	keys := make([]string, 0, len(e.Invalid))
+	for key := range e.Invalid {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("invalid answer for %s: %s", key, e.Invalid[key]))
+	}
+	return "huh: " + strings.Join(parts, "; ")
+}
+
+// valueSetter is implemented by fields whose value can be set from an
+// arbitrary value, such as a prefilled answer.
+//
+// Values are converted to the type of the field where possible, for instance
+// from strings read from the environment, and validated with the field's
+// validation function. The field is left untouched when an error is returned.
+type valueSetter interface {
+	setValue(value any) error
+}
+
+// WithAnswers prefills the fields of the form with the given answers, by key.
+//
+// When every field with a key has an answer the form completes without
+// prompting, which allows running it without a terminal. Otherwise the
+// remaining fields are prompted, unless the form is non-interactive. See
+// WithNonInteractive.
+//
+// Answers given here take precedence over environment variables and answer
+// files.
+func (f *Form) WithAnswers(answers map[string]any) *Form {
+	if f.answers == nil {
+		f.answers = make(map[string]any)
+	}
+	for key, value := range answers {
+		f.answers[key] = value
+	}
+	return f
+}
+
+// WithAnswersFile prefills the fields of the form with answers read from a
+// JSON file containing an object of answers by key.
+//
+// Errors reading the file are returned when the form is run.
+func (f *Form) WithAnswersFile(path string) *Form {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		f.answersErr = fmt.Errorf("huh: reading answers: %w", err)
+		return f
+	}
+	answers := make(map[string]any)
+	if err := json.Unmarshal(data, &answers); err != nil {
+		f.answersErr = fmt.Errorf("huh: decoding answers: %w", err)
+		return f
+	}
+	if f.fileAnswers == nil {
+		f.fileAnswers = make(map[string]any)
+	}
+	for key, value := range answers {
+		f.fileAnswers[key] = value
+	}
+	return f
+}
+
+// WithEnvAnswers prefills the fields of the form with answers read from
+// HUH_<KEY> environment variables, where <KEY> is the field's key in upper
+// case with every character other than letters and digits replaced by an
+// underscore. For example, the field with the key "user.name" is answered by
+// HUH_USER_NAME.
+//
+// Environment variables take precedence over answer files.
+func (f *Form) WithEnvAnswers() *Form {
+	f.envAnswers = true
+	return f
+}
+
+// WithNonInteractive sets whether the form must be completed from prefilled
+// answers only. When set, running the form returns an *AnswersError naming
+// the missing keys instead of prompting for them.
+func (f *Form) WithNonInteractive(v bool) *Form {
+	f.nonInteractive = v
+	return f
+}
+
+// AnswersFromFlags returns the answers given by the flags set on the command
+// line, by flag name, for use with Form.WithAnswers.
+func AnswersFromFlags(fs *flag.FlagSet) map[string]any {
+	answers := make(map[string]any)
+	fs.Visit(func(fl *flag.Flag) {
+		if getter, ok := fl.Value.(flag.Getter); ok {
+			answers[fl.Name] = getter.Get()
+			return
+		}
+		answers[fl.Name] = fl.Value.String()
+	})
+	return answers
+}
+
+// envAnswerName returns the environment variable answering the given key.
+func envAnswerName(key string) string {
+	return envAnswerPrefix + strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, key)
+}
+
+// hasAnswers returns whether the form is prefilled from any answer source.
+func (f *Form) hasAnswers() bool {
+	return len(f.answers) > 0 || len(f.fileAnswers) > 0 || f.envAnswers || f.nonInteractive
+}
+
+// answer returns the prefilled answer for the given key.
+func (f *Form) answer(key string) (any, bool) {
+	if v, ok := f.answers[key]; ok {
+		return v, true
+	}
+	if f.envAnswers {
+		if v, ok := os.LookupEnv(envAnswerName(key)); ok {
+			return v, true
+		}
+	}
+	v, ok := f.fileAnswers[key]
+	return v, ok
+}
+
+// applyAnswers prefills the fields of the form with the answers by key.
+//
+// Groups are visited in order, so that hidden groups and fields can depend on
+// earlier answers, and hidden fields and the fields of hidden groups are
+// ignored. It returns whether every field with a key has been answered, or an
+// error if an answer is invalid or missing in a non-interactive form.
+func (f *Form) applyAnswers() (bool, error) {
+	if f.answersErr != nil {
+		return false, f.answersErr
+	}
+
+	answersErr := &AnswersError{Invalid: make(map[string]error)}
+	f.selector.Range(func(_ int, group *Group) bool {
+		if f.isGroupHidden(group) {
+			return true
+		}
+		group.selector.Range(func(_ int, field Field) bool {
+			key := field.GetKey()
+			if key == "" || isFieldHidden(field) {
+				return true
+			}
+			value, ok := f.answer(key)
+			if !ok {
+				answersErr.Missing = append(answersErr.Missing, key)
+				return true
+			}
+			setter, ok := field.(valueSetter)
+			if !ok {
+				answersErr.Invalid[key] = fmt.Errorf("field does not support answers")
+				return true
+			}
+			if err := setter.setValue(value); err != nil {
+				answersErr.Invalid[key] = err
+				return true
+			}
+			f.results[key] = field.GetValue()
+			return true
+		})
+		return true
+	})
+	f.UpdateFieldPositions()
+
+	if len(answersErr.Invalid) > 0 || (f.nonInteractive && len(answersErr.Missing) > 0) {
+		return false, answersErr
+	}
+	return len(answersErr.Missing) == 0, nil
+}
+
+// validateAnswers runs the validation functions of the visible groups and of
+// the form once all the answers have been applied.
+func (f *Form) validateAnswers() error {
+	var err error
+	f.selector.Range(func(_ int, group *Group) bool {
+		if f.isGroupHidden(group) {
+			return true
+		}
+		err = group.runValidation()
+		return err == nil
+	})
+	if err == nil && f.validate != nil {
+		err = f.validate(f.Results())
+	}
+	if err != nil {
+		return fmt.Errorf("huh: %w", err)
+	}
+	return nil
+}
+
+// answerString converts an answer to a string. Whole numbers decoded from
+// JSON as floats are written without an exponent, so that 1000000 doesn't
+// become "1e+06".
+func answerString(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+// answerBool converts an answer to a bool.
+func answerBool(value any) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("%q is not a boolean", v)
+		}
+		return b, nil
+	}
+	return false, fmt.Errorf("%v is not a boolean", value)
+}
+
+// answerList converts an answer to a list of values. Strings are split on
+// commas.
+func answerList(value any) []any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []any:
+		return v
+	case []string:
+		values := make([]any, len(v))
+		for i, s := range v {
+			values[i] = s
+		}
+		return values
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil
+		}
+		parts := strings.Split(v, ",")
+		values := make([]any, len(parts))
+		for i, s := range parts {
+			values[i] = strings.TrimSpace(s)
+		}
+		return values
+	}
+	return []any{value}
+}
+
+// findOption returns the index of the option matching an answer, either by
+// value or by key. Group headers and separators are never matched.
+func findOption[T comparable](options []Option[T], value any) int {
+	if v, ok := value.(T); ok {
+		for i, o := range options {
+			if o.isChoice() && o.Value == v {
+				return i
+			}
+		}
+	}
+	s := answerString(value)
+	for i, o := range options {
+		if o.isChoice() && fmt.Sprint(o.Value) == s {
+			return i
+		}
+	}
+	for i, o := range options {
+		if o.isChoice() && o.Key == s {
+			return i
+		}
+	}
+	return -1
+}
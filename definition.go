@@ -0,0 +1,516 @@
+package huh
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FormDefinition is a declarative description of a form.
+//
+// Definitions can be decoded from JSON with NewFormFromJSON, or from the same
+// document written in YAML with NewFormFromYAML.
+//
+//	{
+//	  "groups": [{
+//	    "title": "Account",
+//	    "fields": [
+//	      {"type": "input", "key": "name", "title": "Name", "validate": "notempty"},
+//	      {"type": "select", "key": "plan", "title": "Plan", "options": [
+//	        {"key": "Free", "value": "free"},
+//	        {"key": "Pro", "value": "pro"}
+//	      ]}
+//	    ]
+//	  }, {
+//	    "hide": {"key": "plan", "equals": "free"},
+//	    "fields": [
+//	      {"type": "input", "key": "card", "title": "Card number"},
+//	      {"type": "input", "key": "vat", "title": "VAT number",
+//	       "hide": {"key": "plan", "not_equals": "pro"}}
+//	    ]
+//	  }]
+//	}
+type FormDefinition struct {
+	Groups []GroupDefinition `json:"groups" yaml:"groups"`
+}
+
+// GroupDefinition is a declarative description of a group.
+type GroupDefinition struct {
+	Title       string            `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Hide        *Condition        `json:"hide,omitempty" yaml:"hide,omitempty"`
+	Fields      []FieldDefinition `json:"fields" yaml:"fields"`
+}
+
+// FieldDefinition is a declarative description of a field.
+//
+// Type is one of input, text, confirm, select, multiselect, number, integer,
+// date, datetime, note or filepicker. Options not relevant to the type are ignored. Every field but
+// notes must have a Key, which is used to retrieve its value from the form.
+// Hide hides the field based on earlier answers, like the Hide of a group.
+type FieldDefinition struct {
+	Type        string     `json:"type" yaml:"type"`
+	Key         string     `json:"key,omitempty" yaml:"key,omitempty"`
+	Title       string     `json:"title,omitempty" yaml:"title,omitempty"`
+	Description string     `json:"description,omitempty" yaml:"description,omitempty"`
+	Hide        *Condition `json:"hide,omitempty" yaml:"hide,omitempty"`
+
+	// Default is the initial value of the field: a string for input, text,
+	// select and filepicker fields, a bool for confirm fields, a number for
+	// number and integer fields, a list of strings for multiselect fields and
+	// a date such as "2024-12-31" (or "2024-12-31 23:59") for date and
+	// datetime fields.
+	Default any `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// Validate is a pipe separated list of validators for input, text and
+	// filepicker fields: notempty, minlen:N and maxlen:N.
+	Validate string `json:"validate,omitempty" yaml:"validate,omitempty"`
+
+	// Input and text options.
+	Placeholder string `json:"placeholder,omitempty" yaml:"placeholder,omitempty"`
+	CharLimit   int    `json:"char_limit,omitempty" yaml:"char_limit,omitempty"`
+	Echo        string `json:"echo,omitempty" yaml:"echo,omitempty"`
+	Lines       int    `json:"lines,omitempty" yaml:"lines,omitempty"`
+
+	// Select and multiselect options.
+	Options []OptionDefinition `json:"options,omitempty" yaml:"options,omitempty"`
+	Limit   int                `json:"limit,omitempty" yaml:"limit,omitempty"`
+	Height  int                `json:"height,omitempty" yaml:"height,omitempty"`
+	Inline  bool               `json:"inline,omitempty" yaml:"inline,omitempty"`
+
+	// Number and integer options.
+	Min  *float64 `json:"min,omitempty" yaml:"min,omitempty"`
+	Max  *float64 `json:"max,omitempty" yaml:"max,omitempty"`
+	Step *float64 `json:"step,omitempty" yaml:"step,omitempty"`
+
+	// Confirm options.
+	Affirmative string `json:"affirmative,omitempty" yaml:"affirmative,omitempty"`
+	Negative    string `json:"negative,omitempty" yaml:"negative,omitempty"`
+
+	// Note options.
+	Next      bool   `json:"next,omitempty" yaml:"next,omitempty"`
+	NextLabel string `json:"next_label,omitempty" yaml:"next_label,omitempty"`
+
+	// File picker options.
+	AllowedTypes     []string `json:"allowed_types,omitempty" yaml:"allowed_types,omitempty"`
+	CurrentDirectory string   `json:"current_directory,omitempty" yaml:"current_directory,omitempty"`
+}
+
+// OptionDefinition is a declarative description of a select option. When Key
+// is empty the Value is displayed.
+//
+// Consecutive options with the same Group are shown under a header with the
+// name of the group. Disabled options are shown with the Reason they are
+// disabled, if any, but can't be selected.
+type OptionDefinition struct {
+	Key         string `json:"key,omitempty" yaml:"key,omitempty"`
+	Value       string `json:"value" yaml:"value"`
+	Selected    bool   `json:"selected,omitempty" yaml:"selected,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Group       string `json:"group,omitempty" yaml:"group,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty" yaml:"disabled,omitempty"`
+	Reason      string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// Condition matches the value of the field with the given Key. It is used to
+// hide groups and fields based on earlier answers. Exactly one of Equals,
+// NotEquals and Contains must be set.
+//
+// Values are compared by their string representation. Contains matches
+// multiselect fields having the given value selected.
+type Condition struct {
+	Key       string `json:"key" yaml:"key"`
+	Equals    any    `json:"equals,omitempty" yaml:"equals,omitempty"`
+	NotEquals any    `json:"not_equals,omitempty" yaml:"not_equals,omitempty"`
+	Contains  any    `json:"contains,omitempty" yaml:"contains,omitempty"`
+}
+
+// NewFormFromJSON returns a form built from the JSON form definition read from
+// r. See FormDefinition for the format.
+func NewFormFromJSON(r io.Reader) (*Form, error) {
+	var def FormDefinition
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("huh: decoding form definition: %w", err)
+	}
+	return NewFormFromDefinition(def)
+}
+
+// NewFormFromYAML returns a form built from the YAML form definition read from
+// r. See FormDefinition for the format, whose JSON keys are the YAML keys.
+func NewFormFromYAML(r io.Reader) (*Form, error) {
+	var def FormDefinition
+	dec := yaml.NewDecoder(r)
+	dec.KnownFields(true)
+	if err := dec.Decode(&def); err != nil {
+		return nil, fmt.Errorf("huh: decoding form definition: %w", err)
+	}
+	return NewFormFromDefinition(def)
+}
+
+// NewFormFromDefinition returns a form built from the given definition.
+func NewFormFromDefinition(def FormDefinition) (*Form, error) {
+	if len(def.Groups) == 0 {
+		return nil, errors.New("huh: form definition has no groups")
+	}
+
+	fields := make(map[string]Field)
+	groups := make([]*Group, 0, len(def.Groups))
+
+	for gi, gd := range def.Groups {
+		if len(gd.Fields) == 0 {
+			return nil, fmt.Errorf("huh: group %d has no fields", gi)
+		}
+		groupFields := make([]Field, 0, len(gd.Fields))
+		for fi, fd := range gd.Fields {
+			field, err := newDefinedField(fd)
+			if err != nil {
+				return nil, fmt.Errorf("huh: group %d, field %d: %w", gi, fi, err)
+			}
+			if fd.Key != "" {
+				if _, ok := fields[fd.Key]; ok {
+					return nil, fmt.Errorf("huh: duplicate field key %q", fd.Key)
+				}
+				fields[fd.Key] = field
+			}
+			if fd.Hide != nil {
+				hideDefinedField(field, fd.Hide.matcher(fields))
+			}
+			groupFields = append(groupFields, field)
+		}
+
+		group := NewGroup(groupFields...).Title(gd.Title).Description(gd.Description)
+		if gd.Hide != nil {
+			group.WithHideFunc(gd.Hide.matcher(fields))
+		}
+		groups = append(groups, group)
+	}
+
+	// Conditions may only refer to fields that exist.
+	for gi, gd := range def.Groups {
+		if gd.Hide != nil {
+			if err := gd.Hide.check(fields); err != nil {
+				return nil, fmt.Errorf("huh: group %d: %w", gi, err)
+			}
+		}
+		for fi, fd := range gd.Fields {
+			if fd.Hide == nil {
+				continue
+			}
+			if err := fd.Hide.check(fields); err != nil {
+				return nil, fmt.Errorf("huh: group %d, field %d: %w", gi, fi, err)
+			}
+		}
+	}
+
+	return NewForm(groups...), nil
+}
+
+// check returns an error if the condition refers to an unknown field, or
+// doesn't compare its value exactly one way.
+func (c *Condition) check(fields map[string]Field) error {
+	if _, ok := fields[c.Key]; !ok {
+		return fmt.Errorf("hide condition refers to unknown key %q", c.Key)
+	}
+	set := 0
+	for _, v := range []any{c.Equals, c.NotEquals, c.Contains} {
+		if v != nil {
+			set++
+		}
+	}
+	if set != 1 {
+		return fmt.Errorf("hide condition on %q must set exactly one of equals, not_equals or contains", c.Key)
+	}
+	return nil
+}
+
+// matcher returns a function reporting whether the condition matches the
+// current value of the referenced field.
+func (c *Condition) matcher(fields map[string]Field) func() bool {
+	return func() bool {
+		field, ok := fields[c.Key]
+		if !ok {
+			return false
+		}
+		value := field.GetValue()
+		switch {
+		case c.Equals != nil:
+			return fmt.Sprint(value) == fmt.Sprint(c.Equals)
+		case c.NotEquals != nil:
+			return fmt.Sprint(value) != fmt.Sprint(c.NotEquals)
+		case c.Contains != nil:
+			rv := reflect.ValueOf(value)
+			if rv.Kind() != reflect.Slice {
+				return false
+			}
+			for i := 0; i < rv.Len(); i++ {
+				if fmt.Sprint(rv.Index(i).Interface()) == fmt.Sprint(c.Contains) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// newDefinedField creates a field from its definition.
+func newDefinedField(fd FieldDefinition) (Field, error) {
+	if fd.Key == "" && fd.Type != "note" {
+		return nil, fmt.Errorf("%s field has no key", fd.Type)
+	}
+
+	var validate func(string) error
+	if fd.Validate != "" {
+		var err error
+		validate, err = validatorFromSpec(fd.Validate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch fd.Type {
+	case "input":
+		input := NewInput().
+			Key(fd.Key).
+			Title(fd.Title).
+			Description(fd.Description).
+			Placeholder(fd.Placeholder).
+			Inline(fd.Inline)
+		if fd.CharLimit > 0 {
+			input.CharLimit(fd.CharLimit)
+		}
+		if validate != nil {
+			input.Validate(validate)
+		}
+		switch fd.Echo {
+		case "", "normal":
+		case "password":
+			input.EchoMode(EchoModePassword)
+		case "none":
+			input.EchoMode(EchoModeNone)
+		default:
+			return nil, fmt.Errorf("unknown echo mode %q", fd.Echo)
+		}
+		if fd.Default != nil {
+			value, ok := fd.Default.(string)
+			if !ok {
+				return nil, fmt.Errorf("default of input %q must be a string", fd.Key)
+			}
+			input.Value(&value)
+		}
+		return input, nil
+
+	case "text":
+		text := NewText().
+			Key(fd.Key).
+			Title(fd.Title).
+			Description(fd.Description).
+			Placeholder(fd.Placeholder)
+		if fd.CharLimit > 0 {
+			text.CharLimit(fd.CharLimit)
+		}
+		if fd.Lines > 0 {
+			text.Lines(fd.Lines)
+		}
+		if validate != nil {
+			text.Validate(validate)
+		}
+		if fd.Default != nil {
+			value, ok := fd.Default.(string)
+			if !ok {
+				return nil, fmt.Errorf("default of text %q must be a string", fd.Key)
+			}
+			text.Value(&value)
+		}
+		return text, nil
+
+	case "confirm":
+		confirm := NewConfirm().
+			Key(fd.Key).
+			Title(fd.Title).
+			Description(fd.Description).
+			Inline(fd.Inline)
+		if fd.Affirmative != "" {
+			confirm.Affirmative(fd.Affirmative)
+		}
+		if fd.Negative != "" {
+			confirm.Negative(fd.Negative)
+		}
+		if fd.Default != nil {
+			value, ok := fd.Default.(bool)
+			if !ok {
+				return nil, fmt.Errorf("default of confirm %q must be a bool", fd.Key)
+			}
+			confirm.Value(&value)
+		}
+		return confirm, nil
+
+	case "select":
+		if len(fd.Options) == 0 {
+			return nil, fmt.Errorf("select %q has no options", fd.Key)
+		}
+		s := NewSelect[string]().
+			Key(fd.Key).
+			Title(fd.Title).
+			Description(fd.Description).
+			Options(definedOptions(fd.Options)...)
+		if fd.Height > 0 {
+			s.Height(fd.Height)
+		}
+		if fd.Inline {
+			s.Inline(true)
+		}
+		if fd.Default != nil {
+			value, ok := fd.Default.(string)
+			if !ok {
+				return nil, fmt.Errorf("default of select %q must be a string", fd.Key)
+			}
+			s.Value(&value)
+		}
+		return s, nil
+
+	case "multiselect":
+		if len(fd.Options) == 0 {
+			return nil, fmt.Errorf("multiselect %q has no options", fd.Key)
+		}
+		m := NewMultiSelect[string]().
+			Key(fd.Key).
+			Title(fd.Title).
+			Description(fd.Description).
+			Options(definedOptions(fd.Options)...).
+			Limit(fd.Limit)
+		if fd.Height > 0 {
+			m.Height(fd.Height)
+		}
+		if fd.Default != nil {
+			defaults, ok := fd.Default.([]any)
+			if !ok {
+				return nil, fmt.Errorf("default of multiselect %q must be a list", fd.Key)
+			}
+			values := make([]string, len(defaults))
+			for i, v := range defaults {
+				values[i] = fmt.Sprint(v)
+			}
+			m.Value(&values)
+		}
+		return m, nil
+
+	case "number":
+		return newDefinedNumber[float64](fd)
+
+	case "integer":
+		return newDefinedNumber[int](fd)
+
+	case "date", "datetime":
+		d := NewDatePicker()
+		if fd.Type == "datetime" {
+			d = NewDateTime()
+		}
+		d.Key(fd.Key).Title(fd.Title).Description(fd.Description)
+		if fd.Default != nil {
+			if err := d.setValue(fd.Default); err != nil {
+				return nil, fmt.Errorf("default of %s %q: %w", fd.Type, fd.Key, err)
+			}
+		}
+		return d, nil
+
+	case "note":
+		note := NewNote().
+			Title(fd.Title).
+			Description(fd.Description).
+			Next(fd.Next)
+		if fd.NextLabel != "" {
+			note.NextLabel(fd.NextLabel)
+		}
+		if fd.Height > 0 {
+			note.Height(fd.Height)
+		}
+		return note, nil
+
+	case "filepicker":
+		picker := NewFilePicker().
+			Key(fd.Key).
+			Title(fd.Title).
+			Description(fd.Description)
+		if len(fd.AllowedTypes) > 0 {
+			picker.AllowedTypes(fd.AllowedTypes)
+		}
+		if fd.CurrentDirectory != "" {
+			picker.CurrentDirectory(fd.CurrentDirectory)
+		}
+		if fd.Height > 0 {
+			picker.Height(fd.Height)
+		}
+		if validate != nil {
+			picker.Validate(validate)
+		}
+		if fd.Default != nil {
+			value, ok := fd.Default.(string)
+			if !ok {
+				return nil, fmt.Errorf("default of filepicker %q must be a string", fd.Key)
+			}
+			picker.Value(&value)
+		}
+		return picker, nil
+	}
+
+	return nil, fmt.Errorf("unknown field type %q", fd.Type)
+}
+
+// hideDefinedField sets the hide function of a field created from its
+// definition.
+func hideDefinedField(field Field, hide func() bool) {
+	if field, ok := field.(interface{ setHideFunc(func() bool) }); ok {
+		field.setHideFunc(hide)
+	}
+}
+
+// definedOptions returns the select options from their definitions.
+func definedOptions(defs []OptionDefinition) []Option[string] {
+	options := make([]Option[string], 0, len(defs))
+	for i, o := range defs {
+		if o.Group != "" && (i == 0 || defs[i-1].Group != o.Group) {
+			options = append(options, NewOptionHeader[string](o.Group))
+		}
+		key := o.Key
+		if key == "" {
+			key = o.Value
+		}
+		option := NewOption(key, o.Value).Selected(o.Selected).Description(o.Description)
+		if o.Disabled {
+			option = option.Disabled(o.Reason)
+		}
+		options = append(options, option)
+	}
+	return options
+}
+
+// newDefinedNumber creates a number field from its definition.
+func newDefinedNumber[T int | int64 | float64](fd FieldDefinition) (Field, error) {
+	n := NewNumber[T]().
+		Key(fd.Key).
+		Title(fd.Title).
+		Description(fd.Description).
+		Placeholder(fd.Placeholder).
+		Inline(fd.Inline)
+	if fd.Min != nil {
+		n.Min(T(*fd.Min))
+	}
+	if fd.Max != nil {
+		n.Max(T(*fd.Max))
+	}
+	if fd.Step != nil {
+		n.Step(T(*fd.Step))
+	}
+	if fd.Default != nil {
+		if err := n.setValue(fd.Default); err != nil {
+			return nil, fmt.Errorf("default of %s %q: %w", fd.Type, fd.Key, err)
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,202 @@
+package huh
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// AddGroup adds groups at the end of the form.
+//
+// Groups can be added while the form runs, such as from a validation function
+// or from the Update of a model embedding the form, to ask for more of the
+// same, like another server. They get the form's theme, keymap and size.
+func (f *Form) AddGroup(groups ...*Group) *Form {
+	return f.insertGroups(f.selector.Total(), groups)
+}
+
+// InsertGroupAfter inserts groups into the form right after the given group,
+// or at the end of the form if the group is not part of it. See AddGroup.
+func (f *Form) InsertGroupAfter(after *Group, groups ...*Group) *Form {
+	i := f.groupIndex(after)
+	if i < 0 {
+		i = f.selector.Total() - 1
+	}
+	return f.insertGroups(i+1, groups)
+}
+
+// RemoveGroup removes a group from the form. A form keeps at least one group,
+// so the last one left cannot be removed.
+//
+// If the group being filled in is removed, the form moves on to the next
+// visible group, and the command to initialize it is returned.
+func (f *Form) RemoveGroup(group *Group) tea.Cmd {
+	i := f.groupIndex(group)
+	if i < 0 || f.selector.Total() == 1 {
+		return nil
+	}
+
+	focused := i == f.selector.Index()
+	if focused {
+		group.selector.Selected().Blur()
+		group.active = false
+	}
+	f.selector.Remove(i)
+	group.form = nil
+	f.resizeGroups()
+	f.UpdateFieldPositions()
+
+	if focused && f.State == StateNormal {
+		// Look for a visible group from the one that took its place on,
+		// then backwards.
+		next := -1
+		for j := f.selector.Index(); j < f.selector.Total() && next < 0; j++ {
+			if !f.isGroupHidden(f.selector.Get(j)) {
+				next = j
+			}
+		}
+		for j := f.selector.Index() - 1; j >= 0 && next < 0; j-- {
+			if !f.isGroupHidden(f.selector.Get(j)) {
+				next = j
+			}
+		}
+		f.selector.SetIndex(next)
+		f.selector.Selected().active = true
+		return f.selector.Selected().Init()
+	}
+	return nil
+}
+
+// groupIndex returns the index of the group in the form, or -1.
+func (f *Form) groupIndex(group *Group) int {
+	index := -1
+	f.selector.Range(func(i int, g *Group) bool {
+		if g == group {
+			index = i
+		}
+		return index < 0
+	})
+	return index
+}
+
+// insertGroups inserts groups at index i, with the form's settings.
+func (f *Form) insertGroups(i int, groups []*Group) *Form {
+	for j, group := range groups {
+		if group == nil {
+			continue
+		}
+		if f.theme != nil {
+			group.WithTheme(f.theme)
+		}
+		group.WithKeyMap(f.keymap)
+		if f.showHelp != nil {
+			group.WithShowHelp(*f.showHelp)
+		}
+		if f.showErrors != nil {
+			group.WithShowErrors(*f.showErrors)
+		}
+		if f.height > 0 {
+			group.WithHeight(f.height)
+		} else if f.window.Height > 0 && group.fullHeight() > f.window.Height {
+			group.WithHeight(f.window.Height)
+		}
+		group.form = f
+		f.selector.Insert(i+j, group)
+	}
+	f.resizeGroups()
+	f.UpdateFieldPositions()
+	return f
+}
+
+// resizeGroups sets the width of the groups again, as layouts may size them
+// according to the number of groups.
+func (f *Form) resizeGroups() {
+	width := f.width
+	if width <= 0 {
+		width = f.window.Width
+	}
+	if width <= 0 {
+		return
+	}
+	f.selector.Range(func(_ int, group *Group) bool {
+		group.WithWidth(f.layout.GroupWidth(f, group, width))
+		return true
+	})
+}
+
+// AddField adds fields at the end of the group. The fields get the group's
+// theme, keymap, size and the form's accessible mode.
+//
+// Fields can be added while the form runs, such as from a validation function
+// or from the Update of a model embedding the form. The positions of the
+// fields in the form, which set which key bindings are shown, are updated
+// right away.
+func (g *Group) AddField(fields ...Field) *Group {
+	// Groups fitting their fields grow to fit the new ones.
+	fitted := g.height == g.fullHeight()
+	for _, field := range fields {
+		if field == nil {
+			continue
+		}
+		if g.theme != nil {
+			field.WithTheme(g.theme)
+		}
+		if g.keymap != nil {
+			field.WithKeyMap(g.keymap)
+		}
+		if g.width > 0 {
+			field.WithWidth(g.width)
+		}
+		// As in WithHeight, a field height must not exceed the group height.
+		if !fitted && g.height > 0 && g.height-1 <= lipgloss.Height(field.View()) {
+			field.WithHeight(g.height)
+		}
+		if g.form != nil && g.form.accessible {
+			field.WithAccessible(true)
+		}
+		g.selector.Append(field)
+	}
+	if fitted {
+		g.WithHeight(g.fullHeight())
+	}
+	g.updatePositions()
+	g.buildView()
+	return g
+}
+
+// RemoveField removes a field from the group. A group keeps at least one
+// field, so the last one left cannot be removed.
+//
+// If the focused field is removed, the focus moves to the field that took its
+// place.
+func (g *Group) RemoveField(field Field) *Group {
+	index := -1
+	g.selector.Range(func(i int, f Field) bool {
+		if f == field {
+			index = i
+		}
+		return index < 0
+	})
+	if index < 0 || g.selector.Total() == 1 {
+		return g
+	}
+
+	focused := index == g.selector.Index()
+	if focused {
+		field.Blur()
+	}
+	g.selector.Remove(index)
+	if focused && g.active {
+		g.selector.Selected().Focus()
+	}
+	g.updatePositions()
+	g.buildView()
+	return g
+}
+
+// updatePositions updates the positions of the fields in the form the group
+// is part of. Groups not yet part of a form get them when added to one.
+func (g *Group) updatePositions() {
+	if g.form != nil {
+		g.form.UpdateFieldPositions()
+	}
+}
@@ -1,17 +1,22 @@
 package huh
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/thedeveloper-sharath/huh/accessibility"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // Confirm is a form confirm field.
 type Confirm struct {
+	fieldHide
+
 	accessor Accessor[bool]
 	key      string
 	id       int
@@ -23,8 +28,9 @@ type Confirm struct {
 	negative    string
 
 	// error handling
-	validate func(bool) error
-	err      error
+	validate      func(bool) error
+	validateAsync *asyncValidation[bool]
+	err           error
 
 	// state
 	focused bool
@@ -57,14 +63,41 @@ func (c *Confirm) Validate(validate func(bool) error) *Confirm {
 	return c
 }
 
+// ValidateAsync sets the asynchronous validation function of the confirm
+// field.
+//
+// The function runs as a command whenever the value changes, and its context
+// is cancelled when the value changes again. The field can't be submitted
+// until the validation resolves, so accepting or rejecting no longer moves to
+// the next field.
+func (c *Confirm) ValidateAsync(validate func(context.Context, bool) error) *Confirm {
+	c.validateAsync = newAsyncValidation(c.id, validate)
+	return c
+}
+
 // Error returns the error of the confirm field.
 func (c *Confirm) Error() error {
-	return c.err
+	if c.err != nil {
+		return c.err
+	}
+	return c.validateAsync.error()
 }
 
 // Skip returns whether the confirm should be skipped or should be blocking.
-func (*Confirm) Skip() bool {
-	return false
+// Hidden confirms are skipped.
+func (c *Confirm) Skip() bool { return c.hidden() }
+
+// WithHideFunc sets the function that checks if the confirm should be hidden.
+func (c *Confirm) WithHideFunc(hideFunc func() bool) *Confirm {
+	c.setHideFunc(hideFunc)
+	return c
+}
+
+// WithShowWhen sets the function that checks if the confirm should be shown.
+// It is the opposite of WithHideFunc.
+func (c *Confirm) WithShowWhen(showFunc func() bool) *Confirm {
+	c.setShowWhen(showFunc)
+	return c
 }
 
 // Zoom returns whether the input should be zoomed.
@@ -138,7 +171,7 @@ func (c *Confirm) Inline(inline bool) *Confirm {
 // Focus focuses the confirm field.
 func (c *Confirm) Focus() tea.Cmd {
 	c.focused = true
-	return nil
+	return c.validateAsync.init(c.accessor.Get())
 }
 
 // Blur blurs the confirm field.
@@ -162,6 +195,10 @@ func (c *Confirm) Init() tea.Cmd {
 func (c *Confirm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	if cmd := c.validateAsync.update(msg); cmd != nil {
+		return c, cmd
+	}
+
 	switch msg := msg.(type) {
 	case updateFieldMsg:
 		if ok, hash := c.title.shouldUpdate(); ok {
@@ -201,15 +238,19 @@ func (c *Confirm) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 			c.accessor.Set(!c.accessor.Get())
+			cmds = append(cmds, c.validateAsync.schedule(c.accessor.Get()))
+		case c.validateAsync.blocked() && key.Matches(msg, c.keymap.Prev, c.keymap.Next, c.keymap.Submit):
+			break
 		case key.Matches(msg, c.keymap.Prev):
 			cmds = append(cmds, PrevField)
 		case key.Matches(msg, c.keymap.Next, c.keymap.Submit):
 			cmds = append(cmds, NextField)
-		case key.Matches(msg, c.keymap.Accept):
-			c.accessor.Set(true)
-			cmds = append(cmds, NextField)
-		case key.Matches(msg, c.keymap.Reject):
-			c.accessor.Set(false)
+		case key.Matches(msg, c.keymap.Accept, c.keymap.Reject):
+			c.accessor.Set(key.Matches(msg, c.keymap.Accept))
+			if c.validateAsync != nil {
+				cmds = append(cmds, c.validateAsync.schedule(c.accessor.Get()))
+				break
+			}
 			cmds = append(cmds, NextField)
 		}
 	}
@@ -234,11 +275,12 @@ func (c *Confirm) View() string {
 
 	var sb strings.Builder
 	sb.WriteString(styles.Title.Render(c.title.val))
-	if c.err != nil {
+	sb.WriteString(c.validateAsync.view(styles))
+	if c.Error() != nil {
 		sb.WriteString(styles.ErrorIndicator.String())
 	}
 
-	description := styles.Description.Render(c.description.val)
+	description := markdownDescription(c.theme, styles, c.description.val, c.width)
 
 	if !c.inline && (c.description.val != "" || c.description.fn != nil) {
 		sb.WriteString("\n")
@@ -287,18 +329,44 @@ func (c *Confirm) View() string {
 // Run runs the confirm field in accessible mode.
 func (c *Confirm) Run() error {
 	if c.accessible {
-		return c.runAccessible()
+		return c.RunAccessible(os.Stdout, os.Stdin)
 	}
 	return Run(c)
 }
 
-// runAccessible runs the confirm field in accessible mode.
-func (c *Confirm) runAccessible() error {
-	styles := c.activeStyles()
-	fmt.Println(styles.Title.Render(c.title.val))
-	fmt.Println()
-	c.accessor.Set(accessibility.PromptBool())
-	fmt.Println(styles.SelectedOption.Render("Chose: "+c.String()) + "\n")
+// RunAccessible runs the confirm field in accessible mode, reading answers
+// from r and writing prompts to w.
+func (c *Confirm) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	a.announce(c.title.val, plainDescription(c.theme, c.description.val),
+		fmt.Sprintf("Enter y for %s or n for %s.", plain(c.affirmative), plain(c.negative)))
+
+	current := "n"
+	if c.accessor.Get() {
+		current = "y"
+	}
+	parse := func(s string) (bool, error) {
+		switch strings.ToLower(s) {
+		case "y", "yes", strings.ToLower(c.affirmative):
+			return true, nil
+		case "n", "no", strings.ToLower(c.negative):
+			return false, nil
+		}
+		return false, errors.New("enter y or n")
+	}
+	input, err := a.prompt("Choose", current, func(s string) error {
+		value, err := parse(s)
+		if err != nil {
+			return err
+		}
+		return withAsyncValidation(c.validate, c.validateAsync)(value)
+	})
+	if err != nil {
+		return err
+	}
+	value, _ := parse(input)
+	c.accessor.Set(value)
+	a.answer(c.String())
 	return nil
 }
 
@@ -359,3 +427,22 @@ func (c *Confirm) GetKey() string {
 func (c *Confirm) GetValue() any {
 	return c.accessor.Get()
 }
+
+// summarize returns the title of the confirm and the label of its answer for
+// the review page.
+func (c *Confirm) summarize() (string, string) {
+	return c.title.val, c.String()
+}
+
+// setValue sets the value of the field from an answer.
+func (c *Confirm) setValue(value any) error {
+	b, err := answerBool(value)
+	if err != nil {
+		return err
+	}
+	if err := withAsyncValidation(c.validate, c.validateAsync)(b); err != nil {
+		return err
+	}
+	c.accessor.Set(b)
+	return nil
+}
@@ -0,0 +1,634 @@
+package huh
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	dateLayout     = "2006-01-02"
+	dateTimeLayout = "2006-01-02 15:04"
+)
+
+// DatePicker is a date picker field.
+//
+// The date picker shows a calendar of the month of the selected date, which
+// is navigated with the arrow keys. Dates outside of the Min and Max bounds
+// and dates rejected by DisabledDates can't be submitted. A date time picker,
+// created with NewDateTime, also lets the user pick the time of day.
+type DatePicker struct {
+	fieldHide
+
+	accessor Accessor[time.Time]
+	key      string
+
+	// customization
+	title       string
+	description string
+	layout      string
+	withTime    bool
+	weekStart   time.Weekday
+	minuteStep  int
+
+	// bounds
+	min      time.Time
+	max      time.Time
+	disabled func(time.Time) bool
+
+	// error handling
+	validate func(time.Time) error
+	err      error
+
+	// state
+	cursor      time.Time
+	focused     bool
+	editingTime bool
+	editingHour bool
+
+	// options
+	width      int
+	height     int
+	accessible bool
+	theme      *Theme
+	keymap     DatePickerKeyMap
+}
+
+// NewDatePicker returns a new date picker field.
+func NewDatePicker() *DatePicker {
+	return &DatePicker{
+		accessor:   &EmbeddedAccessor[time.Time]{},
+		layout:     dateLayout,
+		minuteStep: 1,
+		disabled:   func(time.Time) bool { return false },
+		validate:   func(time.Time) error { return nil },
+	}
+}
+
+// NewDateTime returns a new date picker field which also lets the user pick
+// the time of day.
+func NewDateTime() *DatePicker {
+	d := NewDatePicker()
+	d.withTime = true
+	d.layout = dateTimeLayout
+	d.editingHour = true
+	return d
+}
+
+// Value sets the value of the date picker field.
+func (d *DatePicker) Value(value *time.Time) *DatePicker {
+	return d.Accessor(NewPointerAccessor(value))
+}
+
+// Accessor sets the accessor of the date picker field.
+func (d *DatePicker) Accessor(accessor Accessor[time.Time]) *DatePicker {
+	d.accessor = accessor
+	if v := accessor.Get(); !v.IsZero() {
+		d.cursor = d.truncate(v)
+	}
+	return d
+}
+
+// Key sets the key of the date picker field which can be used to retrieve the
+// value after submission.
+func (d *DatePicker) Key(key string) *DatePicker {
+	d.key = key
+	return d
+}
+
+// Title sets the title of the date picker field.
+func (d *DatePicker) Title(title string) *DatePicker {
+	d.title = title
+	return d
+}
+
+// Description sets the description of the date picker field.
+func (d *DatePicker) Description(description string) *DatePicker {
+	d.description = description
+	return d
+}
+
+// Min sets the earliest date that can be picked.
+func (d *DatePicker) Min(t time.Time) *DatePicker {
+	d.min = t
+	return d
+}
+
+// Max sets the latest date that can be picked.
+func (d *DatePicker) Max(t time.Time) *DatePicker {
+	d.max = t
+	return d
+}
+
+// DisabledDates sets the function reporting the dates that can't be picked,
+// such as weekends or holidays. Disabled dates are displayed with the
+// DisabledDate style.
+func (d *DatePicker) DisabledDates(disabled func(time.Time) bool) *DatePicker {
+	if disabled == nil {
+		disabled = func(time.Time) bool { return false }
+	}
+	d.disabled = disabled
+	return d
+}
+
+// WeekStart sets the first day of the week of the calendar. It defaults to
+// Sunday.
+func (d *DatePicker) WeekStart(day time.Weekday) *DatePicker {
+	d.weekStart = day
+	return d
+}
+
+// MinuteStep sets the amount of minutes by which the time of a date time
+// picker changes. It defaults to 1.
+func (d *DatePicker) MinuteStep(step int) *DatePicker {
+	if step > 0 && step < 60 {
+		d.minuteStep = step
+	}
+	return d
+}
+
+// Format sets the layout, as understood by time.Format, used to display the
+// picked date and to parse dates typed in accessible mode.
+func (d *DatePicker) Format(layout string) *DatePicker {
+	d.layout = layout
+	return d
+}
+
+// Validate sets the validation function of the date picker field.
+func (d *DatePicker) Validate(validate func(time.Time) error) *DatePicker {
+	d.validate = validate
+	return d
+}
+
+// Error returns the error of the date picker field.
+func (d *DatePicker) Error() error {
+	return d.err
+}
+
+// Skip returns whether the date picker should be skipped or should be blocking.
+// Hidden date pickers are skipped.
+func (d *DatePicker) Skip() bool { return d.hidden() }
+
+// WithHideFunc sets the function that checks if the date picker should be hidden.
+func (d *DatePicker) WithHideFunc(hideFunc func() bool) *DatePicker {
+	d.setHideFunc(hideFunc)
+	return d
+}
+
+// WithShowWhen sets the function that checks if the date picker should be shown.
+// It is the opposite of WithHideFunc.
+func (d *DatePicker) WithShowWhen(showFunc func() bool) *DatePicker {
+	d.setShowWhen(showFunc)
+	return d
+}
+
+// Zoom returns whether the date picker should be zoomed.
+func (*DatePicker) Zoom() bool {
+	return false
+}
+
+// Focus focuses the date picker field. Without a value, the cursor starts on
+// today, which only becomes the value once picked.
+func (d *DatePicker) Focus() tea.Cmd {
+	d.focused = true
+	if d.cursor.IsZero() {
+		d.cursor = d.clamp(d.truncate(time.Now()))
+	}
+	return nil
+}
+
+// Blur blurs the date picker field.
+func (d *DatePicker) Blur() tea.Cmd {
+	d.focused = false
+	d.editingTime = false
+	d.err = d.check(d.accessor.Get())
+	return nil
+}
+
+// KeyBinds returns the help keybindings for the date picker field.
+func (d *DatePicker) KeyBinds() []key.Binding {
+	return []key.Binding{
+		d.keymap.Left, d.keymap.Right, d.keymap.Up, d.keymap.Down,
+		d.keymap.PrevMonth, d.keymap.NextMonth, d.keymap.Today, d.keymap.Time,
+		d.keymap.Prev, d.keymap.Submit, d.keymap.Next,
+	}
+}
+
+// Init initializes the date picker field.
+func (d *DatePicker) Init() tea.Cmd {
+	return nil
+}
+
+// Update updates the date picker field.
+func (d *DatePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return d, nil
+	}
+
+	d.err = nil
+	if d.cursor.IsZero() {
+		d.cursor = d.clamp(d.truncate(time.Now()))
+	}
+	cursor := d.cursor
+
+	switch {
+	case key.Matches(keyMsg, d.keymap.Prev, d.keymap.Next, d.keymap.Submit):
+		d.updateValue()
+		if d.err = d.check(d.accessor.Get()); d.err != nil {
+			return d, nil
+		}
+		d.editingTime = false
+		if key.Matches(keyMsg, d.keymap.Prev) {
+			return d, PrevField
+		}
+		return d, NextField
+	case key.Matches(keyMsg, d.keymap.Time):
+		d.editingTime = !d.editingTime
+	case d.editingTime:
+		d.updateTime(keyMsg)
+	case key.Matches(keyMsg, d.keymap.Left):
+		d.move(0, -1)
+	case key.Matches(keyMsg, d.keymap.Right):
+		d.move(0, 1)
+	case key.Matches(keyMsg, d.keymap.Up):
+		d.move(0, -7)
+	case key.Matches(keyMsg, d.keymap.Down):
+		d.move(0, 7)
+	case key.Matches(keyMsg, d.keymap.PrevMonth):
+		d.move(-1, 0)
+	case key.Matches(keyMsg, d.keymap.NextMonth):
+		d.move(1, 0)
+	case key.Matches(keyMsg, d.keymap.Today):
+		now := d.truncate(time.Now())
+		d.cursor = d.clamp(time.Date(now.Year(), now.Month(), now.Day(),
+			d.cursor.Hour(), d.cursor.Minute(), 0, 0, d.cursor.Location()))
+	}
+
+	if !d.cursor.Equal(cursor) {
+		d.updateValue()
+	}
+	return d, nil
+}
+
+// updateTime changes the hour or the minute of the cursor while the time is
+// being edited.
+func (d *DatePicker) updateTime(msg tea.KeyMsg) {
+	switch {
+	case key.Matches(msg, d.keymap.Left, d.keymap.Right):
+		d.editingHour = !d.editingHour
+	case key.Matches(msg, d.keymap.Up, d.keymap.Down):
+		delta := 1
+		if key.Matches(msg, d.keymap.Down) {
+			delta = -1
+		}
+		hour, minute := d.cursor.Hour(), d.cursor.Minute()
+		if d.editingHour {
+			hour = (hour + delta + 24) % 24
+		} else {
+			minute = (minute/d.minuteStep*d.minuteStep + delta*d.minuteStep + 60) % 60
+		}
+		d.cursor = d.clamp(time.Date(d.cursor.Year(), d.cursor.Month(), d.cursor.Day(),
+			hour, minute, 0, 0, d.cursor.Location()))
+	}
+}
+
+// move moves the cursor by the given amount of months and days. Moving by
+// months keeps the day of the month when possible, so that moving from
+// January 31st lands on the last day of February.
+func (d *DatePicker) move(months, days int) {
+	c := d.cursor
+	if months != 0 {
+		first := time.Date(c.Year(), c.Month()+time.Month(months), 1,
+			c.Hour(), c.Minute(), 0, 0, c.Location())
+		day := min(c.Day(), daysIn(first))
+		d.cursor = d.clamp(first.AddDate(0, 0, day-1))
+		return
+	}
+	d.cursor = d.clamp(c.AddDate(0, 0, days))
+}
+
+// updateValue sets the value of the accessor to the cursor.
+func (d *DatePicker) updateValue() {
+	if !d.cursor.IsZero() {
+		d.accessor.Set(d.cursor)
+	}
+}
+
+// check returns an error if the given date can't be picked.
+func (d *DatePicker) check(t time.Time) error {
+	if t.IsZero() {
+		return errors.New("no date selected")
+	}
+	if !d.min.IsZero() && t.Before(d.truncate(d.min)) {
+		return fmt.Errorf("must be on or after %s", d.min.Format(d.layout))
+	}
+	if !d.max.IsZero() && t.After(d.max) {
+		return fmt.Errorf("must be on or before %s", d.max.Format(d.layout))
+	}
+	if d.disabled(t) {
+		return fmt.Errorf("%s is not available", t.Format(d.layout))
+	}
+	return d.validate(t)
+}
+
+// truncate drops the parts of a time the picker doesn't let the user pick:
+// seconds, and the time of day of date pickers.
+func (d *DatePicker) truncate(t time.Time) time.Time {
+	if d.withTime {
+		return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), 0, 0, t.Location())
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// clamp keeps a time within the bounds of the picker.
+func (d *DatePicker) clamp(t time.Time) time.Time {
+	if !d.min.IsZero() && t.Before(d.truncate(d.min)) {
+		t = d.truncate(d.min)
+	}
+	if !d.max.IsZero() && t.After(d.max) {
+		t = d.truncate(d.max)
+	}
+	return t
+}
+
+// outOfBounds returns whether no time of the given day can be picked.
+func (d *DatePicker) outOfBounds(day time.Time) bool {
+	end := day.AddDate(0, 0, 1)
+	return (!d.min.IsZero() && !end.After(d.min)) || (!d.max.IsZero() && day.After(d.max))
+}
+
+// daysIn returns the number of days in the month of t.
+func daysIn(t time.Time) int {
+	return time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+}
+
+func (d *DatePicker) activeStyles() *FieldStyles {
+	theme := d.theme
+	if theme == nil {
+		theme = ThemeCharm()
+	}
+	if d.focused {
+		return &theme.Focused
+	}
+	return &theme.Blurred
+}
+
+// View renders the date picker field.
+func (d *DatePicker) View() string {
+	styles := d.activeStyles()
+
+	var sb strings.Builder
+	if d.title != "" {
+		sb.WriteString(styles.Title.Render(d.title))
+		if d.err != nil {
+			sb.WriteString(styles.ErrorIndicator.String())
+		}
+		sb.WriteString("\n")
+	}
+	if d.description != "" {
+		sb.WriteString(markdownDescription(d.theme, styles, d.description, d.width) + "\n")
+	}
+
+	if !d.focused {
+		if v := d.accessor.Get(); !v.IsZero() {
+			sb.WriteString(styles.SelectedOption.Render(v.Format(d.layout)))
+		} else {
+			sb.WriteString(styles.TextInput.Placeholder.Render("No date selected."))
+		}
+		return styles.Base.Render(sb.String())
+	}
+
+	sb.WriteString(d.calendarView(styles))
+	if d.withTime {
+		sb.WriteString("\n" + d.timeView(styles))
+	}
+	return styles.Base.Render(sb.String())
+}
+
+// calendarView renders the calendar of the month of the cursor.
+func (d *DatePicker) calendarView(styles *FieldStyles) string {
+	const cellWidth = 2
+
+	c := d.cursor
+	first := time.Date(c.Year(), c.Month(), 1, 0, 0, 0, 0, c.Location())
+	now := time.Now().In(c.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, c.Location())
+
+	header := styles.PrevIndicator.String() +
+		styles.Option.Render(c.Format("January 2006")) +
+		styles.NextIndicator.String()
+
+	weekdays := make([]string, 7)
+	for i := range weekdays {
+		day := (d.weekStart + time.Weekday(i)) % 7
+		weekdays[i] = styles.Weekday.Render(day.String()[:cellWidth])
+	}
+
+	var rows []string
+	rows = append(rows, header, strings.Join(weekdays, " "))
+
+	offset := (int(first.Weekday()) - int(d.weekStart) + 7) % 7
+	cells := make([]string, 0, 7)
+	for i := 0; i < offset; i++ {
+		cells = append(cells, strings.Repeat(" ", cellWidth))
+	}
+	for day := 1; day <= daysIn(first); day++ {
+		date := time.Date(c.Year(), c.Month(), day, 0, 0, 0, 0, c.Location())
+		cell := fmt.Sprintf("%*d", cellWidth, day)
+
+		style := styles.Option
+		switch {
+		case day == c.Day() && !d.editingTime:
+			style = styles.SelectedDate
+		case d.outOfBounds(date) || d.disabled(date):
+			style = styles.DisabledDate
+		case date.Equal(today):
+			style = styles.Today
+		case day == c.Day():
+			style = styles.SelectedOption
+		}
+		cells = append(cells, style.Render(cell))
+
+		if len(cells) == 7 {
+			rows = append(rows, strings.Join(cells, " "))
+			cells = cells[:0]
+		}
+	}
+	if len(cells) > 0 {
+		rows = append(rows, strings.Join(cells, " "))
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// timeView renders the time of day of a date time picker.
+func (d *DatePicker) timeView(styles *FieldStyles) string {
+	hour := fmt.Sprintf("%02d", d.cursor.Hour())
+	minute := fmt.Sprintf("%02d", d.cursor.Minute())
+
+	hourStyle, minuteStyle := styles.Option, styles.Option
+	if d.editingTime {
+		if d.editingHour {
+			hourStyle = styles.SelectedDate
+		} else {
+			minuteStyle = styles.SelectedDate
+		}
+	}
+	return styles.Weekday.Render("Time ") + hourStyle.Render(hour) + styles.Option.Render(":") + minuteStyle.Render(minute)
+}
+
+// Run runs the date picker field.
+func (d *DatePicker) Run() error {
+	if d.accessible {
+		return d.RunAccessible(os.Stdout, os.Stdin)
+	}
+	return Run(d)
+}
+
+// RunAccessible runs an accessible date picker field, where the date is typed
+// instead of picked from a calendar. Answers are read from r and prompts
+// written to w.
+func (d *DatePicker) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	rules := []string{fmt.Sprintf("Enter a date as %s.", layoutHint(d.layout))}
+	switch {
+	case !d.min.IsZero() && !d.max.IsZero():
+		rules = append(rules, fmt.Sprintf("From %s to %s.", d.min.Format(d.layout), d.max.Format(d.layout)))
+	case !d.min.IsZero():
+		rules = append(rules, fmt.Sprintf("On or after %s.", d.min.Format(d.layout)))
+	case !d.max.IsZero():
+		rules = append(rules, fmt.Sprintf("On or before %s.", d.max.Format(d.layout)))
+	}
+	a.announce(d.title, plainDescription(d.theme, d.description), rules...)
+
+	var current string
+	if value := d.accessor.Get(); !value.IsZero() {
+		current = value.Format(d.layout)
+	}
+	input, err := a.prompt("Date", current, func(s string) error {
+		t, err := d.parse(s)
+		if err != nil {
+			return err
+		}
+		return d.check(t)
+	})
+	if err != nil {
+		return err
+	}
+	t, _ := d.parse(input)
+	d.cursor = t
+	d.accessor.Set(t)
+	a.answer(t.Format(d.layout))
+	return nil
+}
+
+// parse parses a date typed with the layout of the picker.
+func (d *DatePicker) parse(s string) (time.Time, error) {
+	loc := time.Local
+	if !d.cursor.IsZero() {
+		loc = d.cursor.Location()
+	}
+	t, err := time.ParseInLocation(d.layout, strings.TrimSpace(s), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("expected a date like %s", layoutHint(d.layout))
+	}
+	return d.truncate(t), nil
+}
+
+// layoutHint describes a time layout for humans, e.g. YYYY-MM-DD.
+func layoutHint(layout string) string {
+	return strings.NewReplacer(
+		"2006", "YYYY", "01", "MM", "02", "DD", "15", "hh", "04", "mm", "05", "ss",
+	).Replace(layout)
+}
+
+// WithTheme sets the theme of the date picker field.
+func (d *DatePicker) WithTheme(theme *Theme) Field {
+	if d.theme != nil {
+		return d
+	}
+	d.theme = theme
+	return d
+}
+
+// WithKeyMap sets the keymap of the date picker field.
+func (d *DatePicker) WithKeyMap(k *KeyMap) Field {
+	d.keymap = k.DatePicker
+	d.keymap.Time.SetEnabled(d.withTime)
+	return d
+}
+
+// WithAccessible sets the accessible mode of the date picker field.
+func (d *DatePicker) WithAccessible(accessible bool) Field {
+	d.accessible = accessible
+	return d
+}
+
+// WithWidth sets the width of the date picker field.
+func (d *DatePicker) WithWidth(width int) Field {
+	d.width = width
+	return d
+}
+
+// WithHeight sets the height of the date picker field.
+func (d *DatePicker) WithHeight(height int) Field {
+	d.height = height
+	return d
+}
+
+// WithPosition sets the position of the date picker field.
+func (d *DatePicker) WithPosition(p FieldPosition) Field {
+	d.keymap.Prev.SetEnabled(!p.IsFirst())
+	d.keymap.Next.SetEnabled(!p.IsLast())
+	d.keymap.Submit.SetEnabled(p.IsLast())
+	return d
+}
+
+// GetKey returns the key of the field.
+func (d *DatePicker) GetKey() string {
+	return d.key
+}
+
+// GetValue returns the value of the field.
+func (d *DatePicker) GetValue() any {
+	return d.accessor.Get()
+}
+
+// summarize returns the title and the formatted date for the review page.
+func (d *DatePicker) summarize() (string, string) {
+	t := d.accessor.Get()
+	if t.IsZero() {
+		return d.title, ""
+	}
+	return d.title, t.Format(d.layout)
+}
+
+// setValue sets the value of the field from an answer, either a time.Time or
+// a string in RFC 3339 format or in the layout of the picker.
+func (d *DatePicker) setValue(value any) error {
+	t, ok := value.(time.Time)
+	if !ok {
+		s := answerString(value)
+		var err error
+		if t, err = time.Parse(time.RFC3339, s); err != nil {
+			if t, err = d.parse(s); err != nil {
+				return err
+			}
+		}
+	}
+	t = d.truncate(t)
+	if err := d.check(t); err != nil {
+		return err
+	}
+	d.cursor = t
+	d.accessor.Set(t)
+	return nil
+}
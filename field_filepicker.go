@@ -1,8 +1,9 @@
 package huh
 
 import (
+	"context"
 	"errors"
-	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -11,14 +12,16 @@ import (
 	"github.com/charmbracelet/bubbles/filepicker"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/thedeveloper-sharath/huh/accessibility"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // FilePicker is a form file file field.
 type FilePicker struct {
+	fieldHide
+
 	accessor Accessor[string]
 	key      string
+	id       int
 	picker   filepicker.Model
 
 	// state
@@ -30,8 +33,9 @@ type FilePicker struct {
 	description string
 
 	// error handling
-	validate func(string) error
-	err      error
+	validate      func(string) error
+	validateAsync *asyncValidation[string]
+	err           error
 
 	// options
 	width      int
@@ -53,6 +57,7 @@ func NewFilePicker() *FilePicker {
 
 	return &FilePicker{
 		accessor: &EmbeddedAccessor[string]{},
+		id:       nextID(),
 		validate: func(string) error { return nil },
 		picker:   fp,
 	}
@@ -161,14 +166,40 @@ func (f *FilePicker) Validate(validate func(string) error) *FilePicker {
 	return f
 }
 
+// ValidateAsync sets the asynchronous validation function of the file field.
+//
+// The function runs as a command once a file is selected, after the
+// synchronous validation passes, and its context is cancelled when another
+// file is selected. The field can't be submitted until the validation
+// resolves.
+func (f *FilePicker) ValidateAsync(validate func(context.Context, string) error) *FilePicker {
+	f.validateAsync = newAsyncValidation(f.id, validate)
+	return f
+}
+
 // Error returns the error of the file field.
 func (f *FilePicker) Error() error {
-	return f.err
+	if f.err != nil {
+		return f.err
+	}
+	return f.validateAsync.error()
+}
+
+// Skip returns whether the file picker should be skipped or should be blocking.
+// Hidden file pickers are skipped.
+func (f *FilePicker) Skip() bool { return f.hidden() }
+
+// WithHideFunc sets the function that checks if the file picker should be hidden.
+func (f *FilePicker) WithHideFunc(hideFunc func() bool) *FilePicker {
+	f.setHideFunc(hideFunc)
+	return f
 }
 
-// Skip returns whether the file should be skipped or should be blocking.
-func (*FilePicker) Skip() bool {
-	return false
+// WithShowWhen sets the function that checks if the file picker should be shown.
+// It is the opposite of WithHideFunc.
+func (f *FilePicker) WithShowWhen(showFunc func() bool) *FilePicker {
+	f.setShowWhen(showFunc)
+	return f
 }
 
 // Zoom returns whether the input should be zoomed.
@@ -179,7 +210,7 @@ func (f *FilePicker) Zoom() bool {
 // Focus focuses the file field.
 func (f *FilePicker) Focus() tea.Cmd {
 	f.focused = true
-	return f.picker.Init()
+	return tea.Batch(f.picker.Init(), f.validateAsync.init(f.accessor.Get()))
 }
 
 // Blur blurs the file field.
@@ -204,6 +235,10 @@ func (f *FilePicker) Init() tea.Cmd {
 func (f *FilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	f.err = nil
 
+	if cmd := f.validateAsync.update(msg); cmd != nil {
+		return f, cmd
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch {
@@ -213,14 +248,17 @@ func (f *FilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			f.setPicking(true)
 			return f, f.picker.Init()
-		case key.Matches(msg, f.keymap.Close):
-			f.setPicking(false)
-			return f, NextField
-		case key.Matches(msg, f.keymap.Next):
+		case key.Matches(msg, f.keymap.Close, f.keymap.Next):
 			f.setPicking(false)
+			if f.validateAsync.blocked() {
+				return f, nil
+			}
 			return f, NextField
 		case key.Matches(msg, f.keymap.Prev):
 			f.setPicking(false)
+			if f.validateAsync.blocked() {
+				return f, nil
+			}
 			return f, PrevField
 		}
 	}
@@ -231,6 +269,13 @@ func (f *FilePicker) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if didSelect {
 		f.accessor.Set(file)
 		f.setPicking(false)
+		if f.validateAsync != nil {
+			if f.err = f.validate(file); f.err != nil {
+				f.validateAsync.reset()
+				return f, nil
+			}
+			return f, f.validateAsync.schedule(file)
+		}
 		return f, NextField
 	}
 	didSelect, _ = f.picker.DidSelectDisabledFile(msg)
@@ -259,10 +304,10 @@ func (f *FilePicker) View() string {
 
 	var sb strings.Builder
 	if f.title != "" {
-		sb.WriteString(styles.Title.Render(f.title) + "\n")
+		sb.WriteString(styles.Title.Render(f.title) + f.validateAsync.view(styles) + "\n")
 	}
 	if f.description != "" {
-		sb.WriteString(styles.Description.Render(f.description) + "\n")
+		sb.WriteString(markdownDescription(f.theme, styles, f.description, f.width) + "\n")
 	}
 	if f.picking {
 		sb.WriteString(strings.TrimSuffix(f.picker.View(), "\n"))
@@ -295,25 +340,29 @@ func (f *FilePicker) setPicking(v bool) {
 // Run runs the file field.
 func (f *FilePicker) Run() error {
 	if f.accessible {
-		return f.runAccessible()
+		return f.RunAccessible(os.Stdout, os.Stdin)
 	}
 	return Run(f)
 }
 
-// runAccessible runs an accessible file field.
-func (f *FilePicker) runAccessible() error {
-	styles := f.activeStyles()
-	fmt.Println(styles.Title.Render(f.title))
-	fmt.Println()
+// RunAccessible runs an accessible file field, reading answers from r and
+// writing prompts to w.
+func (f *FilePicker) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	var rules []string
+	if len(f.picker.AllowedTypes) > 0 {
+		rules = append(rules, "Allowed file types: "+strings.Join(f.picker.AllowedTypes, ", ")+".")
+	}
+	a.announce(f.title, plainDescription(f.theme, f.description), rules...)
 
 	validateFile := func(s string) error {
 		// is the string a file?
-		if _, err := os.Open(s); err != nil {
+		if info, err := os.Stat(s); err != nil || info.IsDir() {
 			return errors.New("not a file")
 		}
 
 		// is it one of the allowed types?
-		valid := false
+		valid := len(f.picker.AllowedTypes) == 0
 		for _, ext := range f.picker.AllowedTypes {
 			if strings.HasSuffix(s, ext) {
 				valid = true
@@ -325,11 +374,15 @@ func (f *FilePicker) runAccessible() error {
 		}
 
 		// does it pass user validation?
-		return f.validate(s)
+		return withAsyncValidation(f.validate, f.validateAsync)(s)
 	}
 
-	f.accessor.Set(accessibility.PromptString("File: ", validateFile))
-	fmt.Println(styles.SelectedOption.Render(f.accessor.Get() + "\n"))
+	file, err := a.prompt("File", f.accessor.Get(), validateFile)
+	if err != nil {
+		return err
+	}
+	f.accessor.Set(file)
+	a.answer(file)
 	return nil
 }
 
@@ -413,3 +466,18 @@ func (f *FilePicker) GetKey() string {
 func (f *FilePicker) GetValue() any {
 	return f.accessor.Get()
 }
+
+// summarize returns the title and the picked file for the review page.
+func (f *FilePicker) summarize() (string, string) {
+	return f.title, f.accessor.Get()
+}
+
+// setValue sets the value of the field from an answer.
+func (f *FilePicker) setValue(value any) error {
+	s := answerString(value)
+	if err := withAsyncValidation(f.validate, f.validateAsync)(s); err != nil {
+		return err
+	}
+	f.accessor.Set(s)
+	return nil
+}
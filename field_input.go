@@ -1,13 +1,15 @@
 package huh
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/thedeveloper-sharath/huh/accessibility"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -18,6 +20,8 @@ import (
 //
 // The input field supports Suggestions, Placeholder, and Validation.
 type Input struct {
+	fieldHide
+
 	accessor Accessor[string]
 	key      string
 	id       int
@@ -29,10 +33,11 @@ type Input struct {
 
 	textinput textinput.Model
 
-	inline   bool
-	validate func(string) error
-	err      error
-	focused  bool
+	inline        bool
+	validate      func(string) error
+	validateAsync *asyncValidation[string]
+	err           error
+	focused       bool
 
 	accessible bool
 	width      int
@@ -230,11 +235,40 @@ func (i *Input) Validate(validate func(string) error) *Input {
 	return i
 }
 
+// ValidateAsync sets the asynchronous validation function of the input field.
+//
+// The function runs as a command once the user stops typing, after the
+// synchronous validation passes, and its context is cancelled when the value
+// changes again. The field can't be submitted until the validation resolves.
+func (i *Input) ValidateAsync(validate func(context.Context, string) error) *Input {
+	i.validateAsync = newAsyncValidation(i.id, validate)
+	return i
+}
+
 // Error returns the error of the input field.
-func (i *Input) Error() error { return i.err }
+func (i *Input) Error() error {
+	if i.err != nil {
+		return i.err
+	}
+	return i.validateAsync.error()
+}
 
 // Skip returns whether the input should be skipped or should be blocking.
-func (*Input) Skip() bool { return false }
+// Hidden inputs are skipped.
+func (i *Input) Skip() bool { return i.hidden() }
+
+// WithHideFunc sets the function that checks if the input should be hidden.
+func (i *Input) WithHideFunc(hideFunc func() bool) *Input {
+	i.setHideFunc(hideFunc)
+	return i
+}
+
+// WithShowWhen sets the function that checks if the input should be shown.
+// It is the opposite of WithHideFunc.
+func (i *Input) WithShowWhen(showFunc func() bool) *Input {
+	i.setShowWhen(showFunc)
+	return i
+}
 
 // Zoom returns whether the input should be zoomed.
 func (*Input) Zoom() bool { return false }
@@ -242,7 +276,7 @@ func (*Input) Zoom() bool { return false }
 // Focus focuses the input field.
 func (i *Input) Focus() tea.Cmd {
 	i.focused = true
-	return i.textinput.Focus()
+	return tea.Batch(i.textinput.Focus(), i.validateAsync.init(i.textinput.Value()))
 }
 
 // Blur blurs the input field.
@@ -273,6 +307,10 @@ func (i *Input) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
+	if cmd := i.validateAsync.update(msg); cmd != nil {
+		return i, cmd
+	}
+
 	switch msg := msg.(type) {
 	case updateFieldMsg:
 		var cmds []tea.Cmd
@@ -344,27 +382,43 @@ func (i *Input) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, i.keymap.Prev):
 			value := i.textinput.Value()
 			i.err = i.validate(value)
-			if i.err != nil {
+			if i.err != nil || i.validateAsync.blocked() {
 				return i, nil
 			}
 			cmds = append(cmds, PrevField)
 		case key.Matches(msg, i.keymap.Next, i.keymap.Submit):
 			value := i.textinput.Value()
 			i.err = i.validate(value)
-			if i.err != nil {
+			if i.err != nil || i.validateAsync.blocked() {
 				return i, nil
 			}
 			cmds = append(cmds, NextField)
 		}
 	}
 
+	before := i.textinput.Value()
 	i.textinput, cmd = i.textinput.Update(msg)
 	cmds = append(cmds, cmd)
 	i.accessor.Set(i.textinput.Value())
+	if i.textinput.Value() != before {
+		cmds = append(cmds, i.textChanged())
+	}
 
 	return i, tea.Batch(cmds...)
 }
 
+// textChanged updates the value of the input field after its text changed and
+// schedules its asynchronous validation.
+func (i *Input) textChanged() tea.Cmd {
+	value := i.textinput.Value()
+	i.accessor.Set(value)
+	if i.validate(value) != nil {
+		i.validateAsync.reset()
+		return nil
+	}
+	return i.validateAsync.schedule(value)
+}
+
 func (i *Input) activeStyles() *FieldStyles {
 	theme := i.theme
 	if theme == nil {
@@ -397,12 +451,13 @@ func (i *Input) View() string {
 	var sb strings.Builder
 	if i.title.val != "" || i.title.fn != nil {
 		sb.WriteString(styles.Title.Render(i.title.val))
+		sb.WriteString(i.validateAsync.view(styles))
 		if !i.inline {
 			sb.WriteString("\n")
 		}
 	}
 	if i.description.val != "" || i.description.fn != nil {
-		sb.WriteString(styles.Description.Render(i.description.val))
+		sb.WriteString(markdownDescription(i.theme, styles, i.description.val, i.width))
 		if !i.inline {
 			sb.WriteString("\n")
 		}
@@ -415,7 +470,7 @@ func (i *Input) View() string {
 // Run runs the input field in accessible mode.
 func (i *Input) Run() error {
 	if i.accessible {
-		return i.runAccessible()
+		return i.RunAccessible(os.Stdout, os.Stdin)
 	}
 	return i.run()
 }
@@ -425,13 +480,38 @@ func (i *Input) run() error {
 	return Run(i)
 }
 
-// runAccessible runs the input field in accessible mode.
-func (i *Input) runAccessible() error {
-	styles := i.activeStyles()
-	fmt.Println(styles.Title.Render(i.title.val))
-	fmt.Println()
-	i.accessor.Set(accessibility.PromptString("Input: ", i.validate))
-	fmt.Println(styles.SelectedOption.Render("Input: " + i.accessor.Get() + "\n"))
+// RunAccessible runs the input field in accessible mode, reading answers from
+// r and writing prompts to w.
+func (i *Input) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	hidden := i.textinput.EchoMode != textinput.EchoNormal
+
+	var rules []string
+	if i.textinput.CharLimit > 0 {
+		rules = append(rules, fmt.Sprintf("Up to %d characters.", i.textinput.CharLimit))
+	}
+	if hidden {
+		rules = append(rules, "The answer will not be read back.")
+	}
+	a.announce(i.title.val, plainDescription(i.theme, i.description.val), rules...)
+
+	current := i.accessor.Get()
+	if hidden {
+		current = ""
+	}
+	validate := withAsyncValidation(i.validate, i.validateAsync)
+	value, err := a.prompt("Input", current, func(s string) error {
+		if limit := i.textinput.CharLimit; limit > 0 && len([]rune(s)) > limit {
+			return fmt.Errorf("must be at most %d characters", limit)
+		}
+		return validate(s)
+	})
+	if err != nil {
+		return err
+	}
+	i.accessor.Set(value)
+	_, answer := i.summarize()
+	a.answer(answer)
 	return nil
 }
 
@@ -494,3 +574,30 @@ func (i *Input) GetKey() string { return i.key }
 func (i *Input) GetValue() any {
 	return i.accessor.Get()
 }
+
+// sensitive returns whether the input hides its value, in which case it is
+// never saved.
+func (i *Input) sensitive() bool {
+	return i.textinput.EchoMode != textinput.EchoNormal
+}
+
+// summarize returns the title and the answer of the input for the review
+// page. Hidden answers, such as passwords, are masked.
+func (i *Input) summarize() (string, string) {
+	value := i.accessor.Get()
+	if i.sensitive() {
+		value = strings.Repeat("*", len([]rune(value)))
+	}
+	return i.title.val, value
+}
+
+// setValue sets the value of the field from an answer.
+func (i *Input) setValue(value any) error {
+	s := answerString(value)
+	if err := withAsyncValidation(i.validate, i.validateAsync)(s); err != nil {
+		return err
+	}
+	i.accessor.Set(s)
+	i.textinput.SetValue(s)
+	return nil
+}
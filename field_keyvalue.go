@@ -0,0 +1,352 @@
+package huh
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// KeyValue is a form field editing a map of keys and values, such as labels
+// or environment variables, as a table with a key and a value on each row.
+//
+// Rows are added and removed like the entries of a list. Keys are required
+// and must be unique, rows with a duplicate key keep the user on them.
+type KeyValue struct {
+	fieldHide
+
+	accessor Accessor[map[string]string]
+	list     *List[keyValuePair]
+	key      string
+
+	// customization
+	suggestions []string
+
+	// error handling
+	validateKey   func(string) error
+	validateValue func(string) error
+
+	// options
+	accessible bool
+	keymap     KeyValueKeyMap
+}
+
+// keyValuePair is a row of a key-value field.
+type keyValuePair struct {
+	Key, Value string
+}
+
+// NewKeyValue returns a new key-value field.
+func NewKeyValue() *KeyValue {
+	kv := &KeyValue{
+		accessor:      &EmbeddedAccessor[map[string]string]{},
+		validateKey:   func(string) error { return nil },
+		validateValue: func(string) error { return nil },
+	}
+	kv.list = NewList[keyValuePair]().Template(kv.template).Validate(kv.validatePair)
+	kv.list.columns = true
+	return kv
+}
+
+// template returns the inputs editing a row.
+func (kv *KeyValue) template(pair *keyValuePair) []Field {
+	key := NewInput().
+		Title("Key").
+		Inline(true).
+		Validate(func(s string) error { return kv.validateKey(s) }).
+		Value(&pair.Key)
+	if len(kv.suggestions) > 0 {
+		key.Suggestions(kv.suggestions)
+	}
+	value := NewInput().
+		Title("Value").
+		Inline(true).
+		Validate(func(s string) error { return kv.validateValue(s) }).
+		Value(&pair.Value)
+	return []Field{key, value}
+}
+
+// validatePair validates a row, whose key must be set and unique.
+func (kv *KeyValue) validatePair(pair keyValuePair) error {
+	if pair.Key == "" {
+		return errors.New("key is required")
+	}
+	if err := kv.validateKey(pair.Key); err != nil {
+		return err
+	}
+	if err := kv.validateValue(pair.Value); err != nil {
+		return err
+	}
+	// Count the keys of the rows rather than of the value of the list, which
+	// isn't synced while rows are added in accessible mode.
+	var n int
+	for _, row := range kv.list.rows {
+		if row.value.Key == pair.Key {
+			n++
+		}
+	}
+	if n > 1 {
+		return fmt.Errorf("duplicate key %q", pair.Key)
+	}
+	return nil
+}
+
+// ValidateKey sets the validation function of the keys.
+func (kv *KeyValue) ValidateKey(validate func(string) error) *KeyValue {
+	kv.validateKey = validate
+	return kv
+}
+
+// ValidateValue sets the validation function of the values.
+func (kv *KeyValue) ValidateValue(validate func(string) error) *KeyValue {
+	kv.validateValue = validate
+	return kv
+}
+
+// KeySuggestions sets the suggestions to display for autocomplete in the key
+// inputs, such as well-known label names.
+func (kv *KeyValue) KeySuggestions(suggestions []string) *KeyValue {
+	kv.suggestions = suggestions
+	kv.setPairs(kv.accessor.Get())
+	return kv
+}
+
+// Value sets the value of the key-value field.
+func (kv *KeyValue) Value(value *map[string]string) *KeyValue {
+	return kv.Accessor(NewPointerAccessor(value))
+}
+
+// Accessor sets the accessor of the key-value field.
+func (kv *KeyValue) Accessor(accessor Accessor[map[string]string]) *KeyValue {
+	kv.accessor = accessor
+	kv.setPairs(accessor.Get())
+	return kv
+}
+
+// Key sets the key of the key-value field.
+func (kv *KeyValue) Key(key string) *KeyValue {
+	kv.key = key
+	return kv
+}
+
+// Title sets the title of the key-value field.
+func (kv *KeyValue) Title(title string) *KeyValue {
+	kv.list.Title(title)
+	return kv
+}
+
+// Description sets the description of the key-value field.
+func (kv *KeyValue) Description(description string) *KeyValue {
+	kv.list.Description(description)
+	return kv
+}
+
+// setPairs sets the rows from a map, sorted by key.
+func (kv *KeyValue) setPairs(m map[string]string) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]keyValuePair, len(keys))
+	for i, k := range keys {
+		pairs[i] = keyValuePair{k, m[k]}
+	}
+	kv.list.accessor.Set(pairs)
+	kv.list.buildRows()
+}
+
+// sync sets the value of the field from its rows. Rows without a key are
+// left out.
+func (kv *KeyValue) sync() {
+	m := make(map[string]string)
+	for _, pair := range kv.list.accessor.Get() {
+		if pair.Key != "" {
+			m[pair.Key] = pair.Value
+		}
+	}
+	kv.accessor.Set(m)
+}
+
+// Error returns the error of the key-value field.
+func (kv *KeyValue) Error() error { return kv.list.Error() }
+
+// Skip returns whether the key-value field should be skipped or should be
+// blocking. Hidden key-value fields are skipped.
+func (kv *KeyValue) Skip() bool { return kv.hidden() }
+
+// WithHideFunc sets the function that checks if the key-value field should be
+// hidden.
+func (kv *KeyValue) WithHideFunc(hideFunc func() bool) *KeyValue {
+	kv.setHideFunc(hideFunc)
+	return kv
+}
+
+// WithShowWhen sets the function that checks if the key-value field should be
+// shown. It is the opposite of WithHideFunc.
+func (kv *KeyValue) WithShowWhen(showFunc func() bool) *KeyValue {
+	kv.setShowWhen(showFunc)
+	return kv
+}
+
+// Zoom returns whether the key-value field should be zoomed.
+func (*KeyValue) Zoom() bool { return false }
+
+// Focus focuses the key-value field.
+func (kv *KeyValue) Focus() tea.Cmd { return kv.list.Focus() }
+
+// Blur blurs the key-value field.
+func (kv *KeyValue) Blur() tea.Cmd {
+	cmd := kv.list.Blur()
+	kv.sync()
+	return cmd
+}
+
+// KeyBinds returns the help message for the key-value field.
+func (kv *KeyValue) KeyBinds() []key.Binding { return kv.list.KeyBinds() }
+
+// Init initializes the key-value field.
+func (kv *KeyValue) Init() tea.Cmd { return kv.list.Init() }
+
+// Update updates the key-value field.
+func (kv *KeyValue) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	_, cmd := kv.list.Update(msg)
+	kv.sync()
+	return kv, cmd
+}
+
+// nextField moves to the next input of the rows.
+func (kv *KeyValue) nextField() (bool, tea.Cmd) { return kv.list.nextField() }
+
+// prevField moves to the previous input of the rows.
+func (kv *KeyValue) prevField() (bool, tea.Cmd) { return kv.list.prevField() }
+
+// View renders the key-value field.
+func (kv *KeyValue) View() string { return kv.list.View() }
+
+// Run runs the key-value field.
+func (kv *KeyValue) Run() error {
+	if kv.accessible {
+		return kv.RunAccessible(os.Stdout, os.Stdin)
+	}
+	return Run(kv)
+}
+
+// RunAccessible runs the key-value field in accessible mode, reading answers
+// from r and writing prompts to w.
+//
+// The key and value of the existing rows are asked in turn, then the user is
+// asked whether to add another row until they answer no.
+func (kv *KeyValue) RunAccessible(w io.Writer, r io.Reader) error {
+	if err := kv.list.RunAccessible(w, r); err != nil {
+		return err
+	}
+	kv.sync()
+	return nil
+}
+
+// WithTheme sets the theme of the key-value field.
+func (kv *KeyValue) WithTheme(theme *Theme) Field {
+	kv.list.WithTheme(theme)
+	return kv
+}
+
+// WithKeyMap sets the keymap of the key-value field.
+func (kv *KeyValue) WithKeyMap(k *KeyMap) Field {
+	kv.keymap = k.KeyValue
+	kv.list.WithKeyMap(&KeyMap{
+		Input: k.Input,
+		List: ListKeyMap{
+			Add:    k.KeyValue.Add,
+			Remove: k.KeyValue.Remove,
+			// Maps have no order, so entries can't be moved.
+			MoveUp:   key.NewBinding(key.WithDisabled()),
+			MoveDown: key.NewBinding(key.WithDisabled()),
+			Next:     k.KeyValue.Next,
+			Prev:     k.KeyValue.Prev,
+			Submit:   k.KeyValue.Submit,
+		},
+	})
+	return kv
+}
+
+// WithAccessible sets the accessible mode of the key-value field.
+func (kv *KeyValue) WithAccessible(accessible bool) Field {
+	kv.accessible = accessible
+	kv.list.WithAccessible(accessible)
+	return kv
+}
+
+// WithWidth sets the width of the key-value field.
+func (kv *KeyValue) WithWidth(width int) Field {
+	kv.list.WithWidth(width)
+	return kv
+}
+
+// WithHeight sets the height of the key-value field.
+func (kv *KeyValue) WithHeight(height int) Field {
+	kv.list.WithHeight(height)
+	return kv
+}
+
+// WithPosition sets the position of the key-value field.
+func (kv *KeyValue) WithPosition(p FieldPosition) Field {
+	kv.list.WithPosition(p)
+	kv.keymap.Prev.SetEnabled(!p.IsFirst())
+	kv.keymap.Next.SetEnabled(!p.IsLast())
+	kv.keymap.Submit.SetEnabled(p.IsLast())
+	return kv
+}
+
+// GetKey returns the key of the field.
+func (kv *KeyValue) GetKey() string { return kv.key }
+
+// GetValue returns the value of the field.
+func (kv *KeyValue) GetValue() any {
+	return kv.accessor.Get()
+}
+
+// summarize returns the title of the field and its keys and values for the
+// review page.
+func (kv *KeyValue) summarize() (string, string) {
+	pairs := kv.list.accessor.Get()
+	entries := make([]string, len(pairs))
+	for i, pair := range pairs {
+		entries[i] = pair.Key + "=" + pair.Value
+	}
+	return kv.list.title, strings.Join(entries, ", ")
+}
+
+// setValue sets the value of the field from an answer, a map of keys to
+// values.
+func (kv *KeyValue) setValue(value any) error {
+	m := make(map[string]string)
+	switch v := value.(type) {
+	case map[string]string:
+		for k, s := range v {
+			m[k] = s
+		}
+	case map[string]any:
+		for k, s := range v {
+			m[k] = answerString(s)
+		}
+	default:
+		return fmt.Errorf("%v is not a map of keys to values", value)
+	}
+	for k, v := range m {
+		if err := kv.validateKey(k); err != nil {
+			return fmt.Errorf("key %q: %w", k, err)
+		}
+		if err := kv.validateValue(v); err != nil {
+			return fmt.Errorf("value of %q: %w", k, err)
+		}
+	}
+	kv.accessor.Set(m)
+	kv.setPairs(m)
+	return nil
+}
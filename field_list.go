@@ -0,0 +1,730 @@
+package huh
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/thedeveloper-sharath/huh/internal/selector"
+)
+
+// listIndent is the width of the entry numbers in front of the entries of a
+// list.
+const listIndent = 4
+
+// listFieldPosition is the position of the fields of the entries of a list,
+// which can always move on, within the list or out of it.
+var listFieldPosition = FieldPosition{Field: 1, LastField: 2}
+
+// List is a form field for a list of entries whose number is up to the user,
+// such as environment variables or port mappings.
+//
+// Each entry is edited with the fields returned by the template of the list,
+// which are bound to the entry:
+//
+//	type Env struct{ Name, Value string }
+//
+//	huh.NewList[Env]().
+//		Title("Environment").
+//		Template(func(env *Env) []huh.Field {
+//			return []huh.Field{
+//				huh.NewInput().Title("Name").Value(&env.Name),
+//				huh.NewInput().Title("Value").Value(&env.Value),
+//			}
+//		}).
+//		Value(&envs)
+type List[T any] struct {
+	fieldHide
+
+	accessor Accessor[[]T]
+	key      string
+	id       int
+
+	// customization
+	title       string
+	description string
+	template    func(entry *T) []Field
+
+	// entries
+	rows    []*listRow[T]
+	cursor  int
+	columns bool
+
+	// error handling
+	validate func(T) error
+
+	// state
+	focused bool
+
+	// options
+	width       int
+	height      int
+	accessible  bool
+	theme       *Theme
+	keymap      ListKeyMap
+	fieldKeymap *KeyMap
+}
+
+// listRow is an entry of a list, with the fields editing it.
+type listRow[T any] struct {
+	value  *T
+	fields *selector.Selector[Field]
+	err    error
+}
+
+// init initializes the fields of the entry.
+func (r *listRow[T]) init() []tea.Cmd {
+	var cmds []tea.Cmd
+	r.fields.Range(func(_ int, field Field) bool {
+		cmds = append(cmds, field.Init())
+		return true
+	})
+	return cmds
+}
+
+// NewList returns a new list field.
+func NewList[T any]() *List[T] {
+	return &List[T]{
+		accessor: &EmbeddedAccessor[[]T]{},
+		id:       nextID(),
+		validate: func(T) error { return nil },
+	}
+}
+
+// Template sets the function returning the fields editing an entry of the
+// list. It is called for every entry, with a pointer to the entry the fields
+// must be bound to.
+func (l *List[T]) Template(template func(entry *T) []Field) *List[T] {
+	l.template = template
+	l.buildRows()
+	return l
+}
+
+// Validate sets the validation function of the entries of the list.
+//
+// The function runs when the user moves on from an entry, and its error keeps
+// the user on the entry.
+func (l *List[T]) Validate(validate func(T) error) *List[T] {
+	l.validate = validate
+	return l
+}
+
+// Value sets the value of the list field.
+func (l *List[T]) Value(value *[]T) *List[T] {
+	return l.Accessor(NewPointerAccessor(value))
+}
+
+// Accessor sets the accessor of the list field.
+func (l *List[T]) Accessor(accessor Accessor[[]T]) *List[T] {
+	l.accessor = accessor
+	l.buildRows()
+	return l
+}
+
+// Key sets the key of the list field.
+func (l *List[T]) Key(key string) *List[T] {
+	l.key = key
+	return l
+}
+
+// Title sets the title of the list field.
+func (l *List[T]) Title(title string) *List[T] {
+	l.title = title
+	return l
+}
+
+// Description sets the description of the list field.
+func (l *List[T]) Description(description string) *List[T] {
+	l.description = description
+	return l
+}
+
+// buildRows builds the entries of the list from its value.
+func (l *List[T]) buildRows() {
+	values := l.accessor.Get()
+	l.rows = make([]*listRow[T], len(values))
+	for i, value := range values {
+		l.rows[i] = l.newRow(value)
+	}
+	l.cursor = max(0, min(l.cursor, len(l.rows)-1))
+}
+
+// newRow returns an entry with the given value, and the fields editing it.
+func (l *List[T]) newRow(value T) *listRow[T] {
+	entry := new(T)
+	*entry = value
+	var fields []Field
+	if l.template != nil {
+		fields = l.template(entry)
+	}
+	for _, field := range fields {
+		l.setupField(field, len(fields))
+	}
+	return &listRow[T]{value: entry, fields: selector.NewSelector(fields)}
+}
+
+// setupField applies the options of the list to a field of an entry of n
+// fields.
+func (l *List[T]) setupField(field Field, n int) {
+	if l.theme != nil {
+		field.WithTheme(l.theme)
+	}
+	if l.fieldKeymap != nil {
+		field.WithKeyMap(l.fieldKeymap)
+	}
+	if l.width > 0 {
+		field.WithWidth(l.fieldWidth(n))
+	}
+	field.WithAccessible(l.accessible)
+	field.WithPosition(listFieldPosition)
+}
+
+// fieldWidth returns the width of the fields of an entry of n fields, which
+// share the width of the list when displayed side by side.
+func (l *List[T]) fieldWidth(n int) int {
+	width := l.width - listIndent
+	if l.columns && n > 0 {
+		width /= n
+	}
+	return width
+}
+
+// sync sets the value of the list from its entries.
+func (l *List[T]) sync() {
+	values := make([]T, len(l.rows))
+	for i, row := range l.rows {
+		values[i] = *row.value
+	}
+	l.accessor.Set(values)
+}
+
+// focusedField returns the focused field of the focused entry, if any.
+func (l *List[T]) focusedField() Field {
+	if len(l.rows) == 0 || l.rows[l.cursor].fields.Total() == 0 {
+		return nil
+	}
+	return l.rows[l.cursor].fields.Selected()
+}
+
+// Error returns the first error of the entries of the list.
+func (l *List[T]) Error() error {
+	for i, row := range l.rows {
+		if err := row.error(); err != nil {
+			return fmt.Errorf("entry %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// error returns the first error of the fields of the entry, or its
+// validation error.
+func (r *listRow[T]) error() error {
+	var err error
+	r.fields.Range(func(_ int, field Field) bool {
+		if !isFieldHidden(field) {
+			err = field.Error()
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return r.err
+}
+
+// Skip returns whether the list should be skipped or should be blocking.
+// Hidden lists are skipped.
+func (l *List[T]) Skip() bool { return l.hidden() }
+
+// WithHideFunc sets the function that checks if the list should be hidden.
+func (l *List[T]) WithHideFunc(hideFunc func() bool) *List[T] {
+	l.setHideFunc(hideFunc)
+	return l
+}
+
+// WithShowWhen sets the function that checks if the list should be shown.
+// It is the opposite of WithHideFunc.
+func (l *List[T]) WithShowWhen(showFunc func() bool) *List[T] {
+	l.setShowWhen(showFunc)
+	return l
+}
+
+// Zoom returns whether the list should be zoomed.
+func (*List[T]) Zoom() bool { return false }
+
+// Focus focuses the list field.
+func (l *List[T]) Focus() tea.Cmd {
+	l.focused = true
+	if field := l.focusedField(); field != nil {
+		return field.Focus()
+	}
+	return nil
+}
+
+// Blur blurs the list field, validating its entries.
+func (l *List[T]) Blur() tea.Cmd {
+	l.focused = false
+	var cmd tea.Cmd
+	if field := l.focusedField(); field != nil {
+		cmd = field.Blur()
+	}
+	l.sync()
+	for _, row := range l.rows {
+		row.err = l.validate(*row.value)
+	}
+	return cmd
+}
+
+// KeyBinds returns the help message for the list field.
+func (l *List[T]) KeyBinds() []key.Binding {
+	field := l.focusedField()
+	if field == nil {
+		return []key.Binding{l.keymap.Prev, l.keymap.Submit, l.keymap.Next, l.keymap.Add}
+	}
+	binds := append([]key.Binding{}, field.KeyBinds()...)
+	return append(binds, l.keymap.Add, l.keymap.Remove, l.keymap.MoveUp, l.keymap.MoveDown)
+}
+
+// Init initializes the list field.
+func (l *List[T]) Init() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, row := range l.rows {
+		cmds = append(cmds, row.init()...)
+	}
+	return tea.Batch(cmds...)
+}
+
+// Update updates the list field.
+//
+// Keys go to the focused field of the focused entry, unless they add, remove
+// or move entries. Other messages go to the fields of every entry.
+func (l *List[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		field := l.focusedField()
+		switch {
+		case key.Matches(msg, l.keymap.Add):
+			cmds = append(cmds, l.add())
+		case key.Matches(msg, l.keymap.Remove):
+			cmds = append(cmds, l.remove())
+		case key.Matches(msg, l.keymap.MoveUp):
+			l.swap(-1)
+		case key.Matches(msg, l.keymap.MoveDown):
+			l.swap(1)
+		case field != nil:
+			l.rows[l.cursor].err = nil
+			m, cmd := field.Update(msg)
+			l.rows[l.cursor].fields.Set(l.rows[l.cursor].fields.Index(), m.(Field))
+			cmds = append(cmds, cmd)
+		case key.Matches(msg, l.keymap.Prev):
+			cmds = append(cmds, PrevField)
+		case key.Matches(msg, l.keymap.Next, l.keymap.Submit):
+			cmds = append(cmds, NextField)
+		}
+	default:
+		for _, row := range l.rows {
+			row.fields.Range(func(i int, field Field) bool {
+				m, cmd := field.Update(msg)
+				row.fields.Set(i, m.(Field))
+				cmds = append(cmds, cmd)
+				return true
+			})
+		}
+	}
+
+	l.sync()
+	return l, tea.Batch(cmds...)
+}
+
+// add adds an entry after the focused one and focuses it.
+func (l *List[T]) add() tea.Cmd {
+	var zero T
+	row := l.newRow(zero)
+	i := 0
+	if len(l.rows) > 0 {
+		i = l.cursor + 1
+	}
+	l.rows = append(l.rows[:i], append([]*listRow[T]{row}, l.rows[i:]...)...)
+	cmds := row.init()
+	cmds = append(cmds, l.focusRow(i, 0))
+	return tea.Batch(cmds...)
+}
+
+// remove removes the focused entry, focusing the one taking its place.
+func (l *List[T]) remove() tea.Cmd {
+	if len(l.rows) == 0 {
+		return nil
+	}
+	if field := l.focusedField(); field != nil {
+		field.Blur()
+	}
+	l.rows = append(l.rows[:l.cursor], l.rows[l.cursor+1:]...)
+	l.cursor = max(0, min(l.cursor, len(l.rows)-1))
+	if !l.focused || len(l.rows) == 0 {
+		return nil
+	}
+	return l.focusRow(l.cursor, 0)
+}
+
+// swap swaps the focused entry with the one before or after it.
+func (l *List[T]) swap(delta int) {
+	j := l.cursor + delta
+	if len(l.rows) == 0 || j < 0 || j >= len(l.rows) {
+		return
+	}
+	l.rows[l.cursor], l.rows[j] = l.rows[j], l.rows[l.cursor]
+	l.cursor = j
+}
+
+// focusRow moves the focus to the field at index field of the entry at index
+// row.
+func (l *List[T]) focusRow(row, field int) tea.Cmd {
+	var cmds []tea.Cmd
+	if f := l.focusedField(); f != nil {
+		cmds = append(cmds, f.Blur())
+	}
+	l.cursor = row
+	l.rows[row].fields.SetIndex(field)
+	if f := l.focusedField(); f != nil && l.focused {
+		cmds = append(cmds, f.Focus())
+	}
+	return tea.Batch(cmds...)
+}
+
+// listPosition is the position of a field within the entries of a list.
+type listPosition struct {
+	row, field int
+}
+
+// positions returns the positions of the fields of the entries that are not
+// skipped, in order.
+func (l *List[T]) positions() []listPosition {
+	var positions []listPosition
+	for i, row := range l.rows {
+		row.fields.Range(func(j int, field Field) bool {
+			if !field.Skip() {
+				positions = append(positions, listPosition{i, j})
+			}
+			return true
+		})
+	}
+	return positions
+}
+
+// nextField moves to the next field of the entries.
+func (l *List[T]) nextField() (bool, tea.Cmd) {
+	return l.moveField(1)
+}
+
+// prevField moves to the previous field of the entries.
+func (l *List[T]) prevField() (bool, tea.Cmd) {
+	return l.moveField(-1)
+}
+
+// moveField moves the focus by delta fields within the entries. Moving on
+// from an entry validates it first, and keeps the focus on it if it is not
+// valid. It returns false when there is no field to move to, so that the
+// group moves on from the list.
+func (l *List[T]) moveField(delta int) (bool, tea.Cmd) {
+	if len(l.rows) == 0 {
+		return false, nil
+	}
+	positions := l.positions()
+	current := -1
+	for i, p := range positions {
+		if p.row == l.cursor && p.field == l.rows[l.cursor].fields.Index() {
+			current = i
+		}
+	}
+	next := current + delta
+	if current < 0 && delta > 0 {
+		// The focused field is skipped, move to the first field after it.
+		next = 0
+		for next < len(positions) && positions[next].row < l.cursor {
+			next++
+		}
+	}
+
+	leaving := next < 0 || next >= len(positions) || positions[next].row != l.cursor
+	if leaving {
+		row := l.rows[l.cursor]
+		row.err = l.validate(*row.value)
+		if row.err != nil {
+			return true, nil
+		}
+	}
+	if next < 0 || next >= len(positions) {
+		return false, nil
+	}
+	return true, l.focusRow(positions[next].row, positions[next].field)
+}
+
+func (l *List[T]) activeStyles() *FieldStyles {
+	theme := l.theme
+	if theme == nil {
+		theme = ThemeCharm()
+	}
+	if l.focused {
+		return &theme.Focused
+	}
+	return &theme.Blurred
+}
+
+// View renders the list field.
+func (l *List[T]) View() string {
+	styles := l.activeStyles()
+
+	var sb strings.Builder
+	sb.WriteString(styles.Title.Render(l.title))
+	if l.Error() != nil {
+		sb.WriteString(styles.ErrorIndicator.String())
+	}
+	if l.description != "" {
+		sb.WriteString("\n")
+		sb.WriteString(markdownDescription(l.theme, styles, l.description, l.width))
+	}
+
+	if len(l.rows) == 0 {
+		sb.WriteString("\n")
+		sb.WriteString(styles.Description.Render(
+			fmt.Sprintf("No entries, press %s to add one.", l.keymap.Add.Help().Key)))
+	}
+	for i, row := range l.rows {
+		var fields []string
+		row.fields.Range(func(_ int, field Field) bool {
+			if !isFieldHidden(field) {
+				fields = append(fields, field.View())
+			}
+			return true
+		})
+		number := styles.Description
+		if l.focused && i == l.cursor {
+			number = styles.Title
+		}
+		entry := strings.Join(fields, "\n")
+		if l.columns {
+			entry = lipgloss.JoinHorizontal(lipgloss.Top, fields...)
+		}
+		sb.WriteString("\n")
+		sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top,
+			number.Width(listIndent).Render(fmt.Sprintf("%d.", i+1)),
+			entry,
+		))
+	}
+	return styles.Base.Render(sb.String())
+}
+
+// Run runs the list field.
+func (l *List[T]) Run() error {
+	if l.accessible {
+		return l.RunAccessible(os.Stdout, os.Stdin)
+	}
+	return Run(l)
+}
+
+// RunAccessible runs the list field in accessible mode, reading answers from
+// r and writing prompts to w.
+//
+// The fields of the existing entries are asked in turn, then the user is asked
+// whether to add another entry until they answer no.
+func (l *List[T]) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	a.announce(l.title, plainDescription(l.theme, l.description))
+
+	for i := range l.rows {
+		if err := l.runRowAccessible(a, w, r, i); err != nil {
+			return err
+		}
+	}
+	for {
+		label := "Add another?"
+		if len(l.rows) == 0 {
+			label = "Add an entry?"
+		}
+		input, err := a.prompt(label, "n", func(s string) error {
+			_, err := parseYesNo(s)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if add, _ := parseYesNo(input); !add {
+			break
+		}
+		var zero T
+		l.rows = append(l.rows, l.newRow(zero))
+		if err := l.runRowAccessible(a, w, r, len(l.rows)-1); err != nil {
+			return err
+		}
+	}
+
+	l.sync()
+	_, summary := l.summarize()
+	a.answer(summary)
+	return nil
+}
+
+// runRowAccessible asks the fields of the entry at index i until the entry is
+// valid.
+func (l *List[T]) runRowAccessible(a *accessibleRenderer, w io.Writer, r io.Reader, i int) error {
+	row := l.rows[i]
+	for {
+		fmt.Fprintf(w, "Entry %d.\n", i+1)
+		var err error
+		row.fields.Range(func(_ int, field Field) bool {
+			if isFieldHidden(field) {
+				return true
+			}
+			field.Init()
+			field.Focus()
+			err = runAccessible(field, w, r)
+			return err == nil
+		})
+		if err != nil {
+			return err
+		}
+		if row.err = l.validate(*row.value); row.err == nil {
+			return nil
+		}
+		a.reject(row.err)
+	}
+}
+
+// parseYesNo parses a yes or no answer.
+func parseYesNo(s string) (bool, error) {
+	switch strings.ToLower(s) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	}
+	return false, errors.New("enter y or n")
+}
+
+// WithTheme sets the theme of the list field and of the fields of its
+// entries.
+func (l *List[T]) WithTheme(theme *Theme) Field {
+	if l.theme != nil {
+		return l
+	}
+	l.theme = theme
+	l.eachField(func(field Field) { field.WithTheme(theme) })
+	return l
+}
+
+// WithKeyMap sets the keymap of the list field and of the fields of its
+// entries.
+func (l *List[T]) WithKeyMap(k *KeyMap) Field {
+	l.keymap = k.List
+	l.fieldKeymap = k
+	l.eachField(func(field Field) { field.WithKeyMap(k).WithPosition(listFieldPosition) })
+	return l
+}
+
+// WithAccessible sets the accessible mode of the list field.
+func (l *List[T]) WithAccessible(accessible bool) Field {
+	l.accessible = accessible
+	l.eachField(func(field Field) { field.WithAccessible(accessible) })
+	return l
+}
+
+// WithWidth sets the width of the list field.
+func (l *List[T]) WithWidth(width int) Field {
+	l.width = width
+	for _, row := range l.rows {
+		row.fields.Range(func(_ int, field Field) bool {
+			field.WithWidth(l.fieldWidth(row.fields.Total()))
+			return true
+		})
+	}
+	return l
+}
+
+// WithHeight sets the height of the list field.
+func (l *List[T]) WithHeight(height int) Field {
+	l.height = height
+	return l
+}
+
+// WithPosition sets the position of the list field.
+func (l *List[T]) WithPosition(p FieldPosition) Field {
+	l.keymap.Prev.SetEnabled(!p.IsFirst())
+	l.keymap.Next.SetEnabled(!p.IsLast())
+	l.keymap.Submit.SetEnabled(p.IsLast())
+	return l
+}
+
+// eachField calls fn with every field of the entries.
+func (l *List[T]) eachField(fn func(Field)) {
+	for _, row := range l.rows {
+		row.fields.Range(func(_ int, field Field) bool {
+			fn(field)
+			return true
+		})
+	}
+}
+
+// GetKey returns the key of the field.
+func (l *List[T]) GetKey() string {
+	return l.key
+}
+
+// GetValue returns the value of the field.
+func (l *List[T]) GetValue() any {
+	return l.accessor.Get()
+}
+
+// summarize returns the title of the list and its entries for the review
+// page, the answers to the fields of each entry separated by commas.
+func (l *List[T]) summarize() (string, string) {
+	entries := make([]string, len(l.rows))
+	for i, row := range l.rows {
+		var answers []string
+		row.fields.Range(func(_ int, field Field) bool {
+			if field.Skip() {
+				return true
+			}
+			if s, ok := field.(summarizer); ok {
+				_, answer := s.summarize()
+				answers = append(answers, answer)
+			} else {
+				answers = append(answers, fmt.Sprint(field.GetValue()))
+			}
+			return true
+		})
+		entries[i] = strings.Join(answers, ", ")
+	}
+	return l.title, strings.Join(entries, "; ")
+}
+
+// setValue sets the value of the field from an answer, either a list of
+// entries or anything encoding to a list of entries in JSON, such as saved
+// answers.
+func (l *List[T]) setValue(value any) error {
+	values, ok := value.([]T)
+	if !ok {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &values); err != nil {
+			return fmt.Errorf("not a list of entries: %w", err)
+		}
+	}
+	for i, v := range values {
+		if err := l.validate(v); err != nil {
+			return fmt.Errorf("entry %d: %w", i+1, err)
+		}
+	}
+	l.accessor.Set(values)
+	l.buildRows()
+	return nil
+}
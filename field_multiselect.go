@@ -1,7 +1,11 @@
 package huh
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,12 +14,13 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/thedeveloper-sharath/huh/accessibility"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // MultiSelect is a form multi-select field.
 type MultiSelect[T comparable] struct {
+	fieldHide
+
 	accessor Accessor[[]T]
 	key      string
 	id       int
@@ -26,18 +31,21 @@ type MultiSelect[T comparable] struct {
 	options         Eval[[]Option[T]]
 	filterable      bool
 	filteredOptions []Option[T]
+	preview         *optionPreview[T]
 	limit           int
 	height          int
 
 	// error handling
-	validate func([]T) error
-	err      error
+	validate      func([]T) error
+	validateAsync *asyncValidation[[]T]
+	err           error
 
 	// state
 	cursor    int
 	focused   bool
 	filtering bool
 	filter    textinput.Model
+	filterFn  FilterFunc
 	viewport  viewport.Model
 	spinner   spinner.Model
 
@@ -55,15 +63,18 @@ func NewMultiSelect[T comparable]() *MultiSelect[T] {
 
 	s := spinner.New(spinner.WithSpinner(spinner.Line))
 
+	id := nextID()
 	return &MultiSelect[T]{
 		accessor:    &EmbeddedAccessor[[]T]{},
 		validate:    func([]T) error { return nil },
 		filtering:   false,
 		filter:      filter,
-		id:          nextID(),
+		filterFn:    FilterContains,
+		id:          id,
 		options:     Eval[[]Option[T]]{cache: make(map[uint64][]Option[T])},
 		title:       Eval[string]{cache: make(map[uint64]string)},
 		description: Eval[string]{cache: make(map[uint64]string)},
+		preview:     newOptionPreview[T](id),
 		spinner:     s,
 		filterable:  true,
 	}
@@ -77,14 +88,7 @@ func (m *MultiSelect[T]) Value(value *[]T) *MultiSelect[T] {
 // Accessor sets the accessor of the input field.
 func (m *MultiSelect[T]) Accessor(accessor Accessor[[]T]) *MultiSelect[T] {
 	m.accessor = accessor
-	for i, o := range m.options.val {
-		for _, v := range m.accessor.Get() {
-			if o.Value == v {
-				m.options.val[i].selected = true
-				break
-			}
-		}
-	}
+	m.selectValues(m.options.val)
 	return m
 }
 
@@ -128,16 +132,10 @@ func (m *MultiSelect[T]) Options(options ...Option[T]) *MultiSelect[T] {
 		return m
 	}
 
-	for i, o := range options {
-		for _, v := range m.accessor.Get() {
-			if o.Value == v {
-				options[i].selected = true
-				break
-			}
-		}
-	}
+	m.selectValues(options)
 	m.options.val = options
 	m.filteredOptions = options
+	m.cursor = nearestOption(optionSlice[T](options), m.cursor, 1)
 	m.updateViewportHeight()
 	return m
 }
@@ -169,6 +167,19 @@ func (m *MultiSelect[T]) Filtering(filtering bool) *MultiSelect[T] {
 	return m
 }
 
+// Filter sets the func used to match and rank options against the filter.
+//
+// By default options are matched with FilterContains. Use FilterFuzzy,
+// FilterPrefix, FilterIgnoreDiacritics or a FilterFunc of your own for other
+// strategies. A nil func restores the default.
+func (m *MultiSelect[T]) Filter(fn FilterFunc) *MultiSelect[T] {
+	if fn == nil {
+		fn = FilterContains
+	}
+	m.filterFn = fn
+	return m
+}
+
 // Limit sets the limit of the multi-select field.
 func (m *MultiSelect[T]) Limit(limit int) *MultiSelect[T] {
 	m.limit = limit
@@ -185,20 +196,80 @@ func (m *MultiSelect[T]) Height(height int) *MultiSelect[T] {
 	return m
 }
 
+// Preview sets the function rendering a preview of the option under the
+// cursor, such as the contents of a file or the details of a record.
+//
+// Previews are computed in a command when the cursor moves to an option, and
+// cached by the value of the option. They are shown in a pane to the right of
+// the options unless PreviewPosition says otherwise, and scrolled with
+// shift+up and shift+down.
+func (m *MultiSelect[T]) Preview(preview func(T) string) *MultiSelect[T] {
+	m.preview.fn = preview
+	m.keymap.PreviewUp.SetEnabled(preview != nil)
+	m.keymap.PreviewDown.SetEnabled(preview != nil)
+	m.updateViewportHeight()
+	return m
+}
+
+// PreviewPosition sets whether the preview is shown to the right of the
+// options or below them. Fields too narrow for a pane show the preview
+// inline, below the options.
+func (m *MultiSelect[T]) PreviewPosition(position PreviewPosition) *MultiSelect[T] {
+	m.preview.position = position
+	m.updateViewportHeight()
+	return m
+}
+
+// PreviewSize sets the width of a preview pane to the right of the options,
+// or the height of a preview below them. By default a pane to the right takes
+// half of the field and a preview below takes 6 lines.
+func (m *MultiSelect[T]) PreviewSize(size int) *MultiSelect[T] {
+	m.preview.size = size
+	m.updateViewportHeight()
+	return m
+}
+
 // Validate sets the validation function of the multi-select field.
 func (m *MultiSelect[T]) Validate(validate func([]T) error) *MultiSelect[T] {
 	m.validate = validate
 	return m
 }
 
+// ValidateAsync sets the asynchronous validation function of the multi-select
+// field.
+//
+// The function runs as a command once the selection settles, after the
+// synchronous validation passes, and its context is cancelled when the
+// selection changes again. The field can't be submitted until the validation
+// resolves.
+func (m *MultiSelect[T]) ValidateAsync(validate func(context.Context, []T) error) *MultiSelect[T] {
+	m.validateAsync = newAsyncValidation(m.id, validate)
+	return m
+}
+
 // Error returns the error of the multi-select field.
 func (m *MultiSelect[T]) Error() error {
-	return m.err
+	if m.err != nil {
+		return m.err
+	}
+	return m.validateAsync.error()
 }
 
 // Skip returns whether the multiselect should be skipped or should be blocking.
-func (*MultiSelect[T]) Skip() bool {
-	return false
+// Hidden multiselects are skipped.
+func (m *MultiSelect[T]) Skip() bool { return m.hidden() }
+
+// WithHideFunc sets the function that checks if the multiselect should be hidden.
+func (m *MultiSelect[T]) WithHideFunc(hideFunc func() bool) *MultiSelect[T] {
+	m.setHideFunc(hideFunc)
+	return m
+}
+
+// WithShowWhen sets the function that checks if the multiselect should be shown.
+// It is the opposite of WithHideFunc.
+func (m *MultiSelect[T]) WithShowWhen(showFunc func() bool) *MultiSelect[T] {
+	m.setShowWhen(showFunc)
+	return m
 }
 
 // Zoom returns whether the multiselect should be zoomed.
@@ -210,7 +281,7 @@ func (*MultiSelect[T]) Zoom() bool {
 func (m *MultiSelect[T]) Focus() tea.Cmd {
 	m.updateValue()
 	m.focused = true
-	return nil
+	return m.validateAsync.init(m.accessor.Get())
 }
 
 // Blur blurs the multi-select field.
@@ -242,6 +313,8 @@ func (m *MultiSelect[T]) KeyBinds() []key.Binding {
 		m.keymap.Next,
 		m.keymap.SelectAll,
 		m.keymap.SelectNone,
+		m.keymap.PreviewUp,
+		m.keymap.PreviewDown,
 	)
 	return binds
 }
@@ -259,6 +332,10 @@ func (m *MultiSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// be applied before we can calculate the height.
 	m.updateViewportHeight()
 
+	if cmd := m.validateAsync.update(msg); cmd != nil {
+		return m, cmd
+	}
+
 	var cmd tea.Cmd
 	if m.filtering {
 		m.filter, cmd = m.filter.Update(msg)
@@ -292,7 +369,7 @@ func (m *MultiSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.options.loadFromCache() {
 				m.filteredOptions = m.options.val
 				m.updateValue()
-				m.cursor = clamp(m.cursor, 0, len(m.filteredOptions)-1)
+				m.cursor = nearestOption(optionSlice[T](m.filteredOptions), clamp(m.cursor, 0, len(m.filteredOptions)-1), 1)
 			} else {
 				m.options.loading = true
 				m.options.loadingStart = time.Now()
@@ -301,6 +378,7 @@ func (m *MultiSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}, m.spinner.Tick)
 			}
 		}
+		fieldCmds = append(fieldCmds, m.updatePreview())
 
 		return m, tea.Batch(fieldCmds...)
 
@@ -322,14 +400,21 @@ func (m *MultiSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case updateOptionsMsg[T]:
 		if msg.id == m.id && msg.hash == m.options.bindingsHash {
 			m.options.update(msg.options)
+			m.selectValues(m.options.val)
 			// since we're updating the options, we need to reset the cursor.
 			m.filteredOptions = m.options.val
 			m.updateValue()
-			m.cursor = clamp(m.cursor, 0, len(m.filteredOptions)-1)
+			m.cursor = nearestOption(optionSlice[T](m.filteredOptions), clamp(m.cursor, 0, len(m.filteredOptions)-1), 1)
 		}
+	case updatePreviewMsg:
+		m.preview.update(msg)
 	case tea.KeyMsg:
 		m.err = nil
 		switch {
+		case key.Matches(msg, m.keymap.PreviewUp):
+			m.preview.scroll(-1)
+		case key.Matches(msg, m.keymap.PreviewDown):
+			m.preview.scroll(1)
 		case key.Matches(msg, m.keymap.Filter):
 			m.setFilter(true)
 			return m, m.filter.Focus()
@@ -349,9 +434,10 @@ func (m *MultiSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 
-			m.cursor = max(m.cursor-1, 0)
-			if m.cursor < m.viewport.YOffset {
-				m.viewport.SetYOffset(m.cursor)
+			m.cursor = nextOption(optionSlice[T](m.filteredOptions), m.cursor, -1, false)
+			// Show the header of the option's group along with it.
+			if top := firstRowOf(optionSlice[T](m.filteredOptions), m.cursor); top < m.viewport.YOffset {
+				m.viewport.SetYOffset(top)
 			}
 		case key.Matches(msg, m.keymap.Down):
 			// FIXME: should use keys in keymap
@@ -359,31 +445,34 @@ func (m *MultiSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 
-			m.cursor = min(m.cursor+1, len(m.filteredOptions)-1)
+			m.cursor = nextOption(optionSlice[T](m.filteredOptions), m.cursor, 1, false)
 			if m.cursor >= m.viewport.YOffset+m.viewport.Height {
-				m.viewport.LineDown(1)
+				m.viewport.SetYOffset(m.cursor - m.viewport.Height + 1)
 			}
 		case key.Matches(msg, m.keymap.GotoTop):
 			if m.filtering {
 				break
 			}
-			m.cursor = 0
+			m.cursor = nearestOption(optionSlice[T](m.filteredOptions), 0, 1)
 			m.viewport.GotoTop()
 		case key.Matches(msg, m.keymap.GotoBottom):
 			if m.filtering {
 				break
 			}
-			m.cursor = len(m.filteredOptions) - 1
+			m.cursor = nearestOption(optionSlice[T](m.filteredOptions), len(m.filteredOptions)-1, -1)
 			m.viewport.GotoBottom()
 		case key.Matches(msg, m.keymap.HalfPageUp):
-			m.cursor = max(m.cursor-m.viewport.Height/2, 0)
+			m.cursor = nearestOption(optionSlice[T](m.filteredOptions), max(m.cursor-m.viewport.Height/2, 0), -1)
 			m.viewport.HalfViewUp()
 		case key.Matches(msg, m.keymap.HalfPageDown):
-			m.cursor = min(m.cursor+m.viewport.Height/2, len(m.filteredOptions)-1)
+			m.cursor = nearestOption(optionSlice[T](m.filteredOptions), min(m.cursor+m.viewport.Height/2, len(m.filteredOptions)-1), 1)
 			m.viewport.HalfViewDown()
 		case key.Matches(msg, m.keymap.Toggle) && !m.filtering:
+			if m.cursor >= len(m.filteredOptions) || !m.filteredOptions[m.cursor].selectable() {
+				break
+			}
 			for i, option := range m.options.val {
-				if option.Key == m.filteredOptions[m.cursor].Key {
+				if option.isChoice() && option.Key == m.filteredOptions[m.cursor].Key {
 					if !m.options.val[m.cursor].selected && m.limit > 0 && m.numSelected() >= m.limit {
 						break
 					}
@@ -394,17 +483,22 @@ func (m *MultiSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.setSelectAllHelp()
 			m.updateValue()
+			cmds = append(cmds, m.scheduleValidation())
 		case key.Matches(msg, m.keymap.SelectAll, m.keymap.SelectNone) && m.limit <= 0:
 			selected := false
 
 			for _, option := range m.filteredOptions {
-				if !option.selected {
+				if option.selectable() && !option.selected {
 					selected = true
 					break
 				}
 			}
 
+			// Disabled options keep their state.
 			for i, option := range m.options.val {
+				if !option.selectable() {
+					continue
+				}
 				for j := range m.filteredOptions {
 					if option.Key == m.filteredOptions[j].Key {
 						m.options.val[i].selected = selected
@@ -415,34 +509,27 @@ func (m *MultiSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.setSelectAllHelp()
 			m.updateValue()
+			cmds = append(cmds, m.scheduleValidation())
 		case key.Matches(msg, m.keymap.Prev):
 			m.updateValue()
 			m.err = m.validate(m.accessor.Get())
-			if m.err != nil {
+			if m.err != nil || m.validateAsync.blocked() {
 				return m, nil
 			}
 			return m, PrevField
 		case key.Matches(msg, m.keymap.Next, m.keymap.Submit):
 			m.updateValue()
 			m.err = m.validate(m.accessor.Get())
-			if m.err != nil {
+			if m.err != nil || m.validateAsync.blocked() {
 				return m, nil
 			}
 			return m, NextField
 		}
 
 		if m.filtering {
-			m.filteredOptions = m.options.val
-			if m.filter.Value() != "" {
-				m.filteredOptions = nil
-				for _, option := range m.options.val {
-					if m.filterFunc(option.Key) {
-						m.filteredOptions = append(m.filteredOptions, option)
-					}
-				}
-			}
+			m.filteredOptions = filterOptions(m.options.val, m.filter.Value(), m.filterFn)
 			if len(m.filteredOptions) > 0 {
-				m.cursor = min(m.cursor, len(m.filteredOptions)-1)
+				m.cursor = nearestOption(optionSlice[T](m.filteredOptions), min(m.cursor, len(m.filteredOptions)-1), 1)
 				m.viewport.SetYOffset(clamp(m.cursor, 0, len(m.filteredOptions)-m.viewport.Height))
 			}
 		}
@@ -463,7 +550,23 @@ func (m *MultiSelect[T]) updateViewportHeight() {
 	const minHeight = 1
 	m.viewport.Height = max(minHeight, m.height-
 		lipgloss.Height(m.titleView())-
-		lipgloss.Height(m.descriptionView()))
+		lipgloss.Height(m.descriptionView())-
+		m.preview.height(m.previewWidth(), m.activeStyles()))
+}
+
+// previewWidth returns the width of the options and their preview.
+func (m *MultiSelect[T]) previewWidth() int {
+	return m.width - m.activeStyles().Base.GetHorizontalFrameSize()
+}
+
+// updatePreview computes the preview of the option under the cursor, or
+// clears it when there is no option to preview.
+func (m *MultiSelect[T]) updatePreview() tea.Cmd {
+	if m.cursor < 0 || m.cursor >= len(m.filteredOptions) || !m.filteredOptions[m.cursor].isChoice() {
+		m.preview.clear()
+		return nil
+	}
+	return m.preview.hover(m.filteredOptions[m.cursor].Value)
 }
 
 // numSelected returns the total number of selected options.
@@ -490,14 +593,49 @@ func (m *MultiSelect[T]) numFilteredSelected() int {
 }
 
 func (m *MultiSelect[T]) updateValue() {
+	m.accessor.Set(m.selectedValues())
+	m.err = m.validate(m.accessor.Get())
+}
+
+// scheduleValidation schedules the asynchronous validation of the selected
+// options, unless they are rejected by the synchronous validation.
+func (m *MultiSelect[T]) scheduleValidation() tea.Cmd {
+	if m.err != nil {
+		m.validateAsync.reset()
+		return nil
+	}
+	return m.validateAsync.schedule(m.accessor.Get())
+}
+
+// syncValue sets the value of the accessor to the selected options without
+// validating it.
+func (m *MultiSelect[T]) syncValue() {
+	if len(m.options.val) > 0 {
+		m.accessor.Set(m.selectedValues())
+	}
+}
+
+// selectedValues returns the values of the selected options.
+func (m *MultiSelect[T]) selectedValues() []T {
 	value := make([]T, 0)
 	for _, option := range m.options.val {
 		if option.selected {
 			value = append(value, option.Value)
 		}
 	}
-	m.accessor.Set(value)
-	m.err = m.validate(m.accessor.Get())
+	return value
+}
+
+// selectValues selects the options whose values are in the accessor's value.
+func (m *MultiSelect[T]) selectValues(options []Option[T]) {
+	for i, o := range options {
+		for _, v := range m.accessor.Get() {
+			if o.isChoice() && o.Value == v {
+				options[i].selected = true
+				break
+			}
+		}
+	}
 }
 
 func (m *MultiSelect[T]) activeStyles() *FieldStyles {
@@ -526,14 +664,15 @@ func (m *MultiSelect[T]) titleView() string {
 	} else {
 		sb.WriteString(styles.Title.Render(m.title.val))
 	}
-	if m.err != nil {
+	sb.WriteString(m.validateAsync.view(styles))
+	if m.Error() != nil {
 		sb.WriteString(styles.ErrorIndicator.String())
 	}
 	return sb.String()
 }
 
 func (m *MultiSelect[T]) descriptionView() string {
-	return m.activeStyles().Description.Render(m.description.val)
+	return markdownDescription(m.theme, m.activeStyles(), m.description.val, m.width)
 }
 
 func (m *MultiSelect[T]) optionsView() string {
@@ -556,12 +695,14 @@ func (m *MultiSelect[T]) optionsView() string {
 			sb.WriteString(strings.Repeat(" ", lipgloss.Width(c)))
 		}
 
-		if m.filteredOptions[i].selected {
+		if !option.isChoice() {
+			sb.WriteString(m.optionView(option, styles.UnselectedOption))
+		} else if m.filteredOptions[i].selected {
 			sb.WriteString(styles.SelectedPrefix.String())
-			sb.WriteString(styles.SelectedOption.Render(option.Key))
+			sb.WriteString(m.optionView(option, styles.SelectedOption))
 		} else {
 			sb.WriteString(styles.UnselectedPrefix.String())
-			sb.WriteString(styles.UnselectedOption.Render(option.Key))
+			sb.WriteString(m.optionView(option, styles.UnselectedOption))
 		}
 		if i < len(m.options.val)-1 {
 			sb.WriteString("\n")
@@ -575,6 +716,12 @@ func (m *MultiSelect[T]) optionsView() string {
 	return sb.String()
 }
 
+// optionView renders an option, highlighting the characters of its key that
+// match the filter.
+func (m *MultiSelect[T]) optionView(option Option[T], style lipgloss.Style) string {
+	return renderOption(option, m.filter.Value(), m.filterFn, style, m.activeStyles())
+}
+
 // View renders the multi-select field.
 func (m *MultiSelect[T]) View() string {
 	styles := m.activeStyles()
@@ -589,26 +736,19 @@ func (m *MultiSelect[T]) View() string {
 	if m.description.val != "" || m.description.fn != nil {
 		sb.WriteString(m.descriptionView() + "\n")
 	}
-	sb.WriteString(m.viewport.View())
+	sb.WriteString(m.preview.view(m.viewport.View(), m.previewWidth(), styles))
 	return styles.Base.Render(sb.String())
 }
 
-func (m *MultiSelect[T]) printOptions() {
-	styles := m.activeStyles()
-	var sb strings.Builder
-	sb.WriteString(styles.Title.Render(m.title.val))
-	sb.WriteString("\n")
-
-	for i, option := range m.options.val {
+// printOptions prints the options for accessible mode and returns the
+// indexes of the numbered choices.
+func (m *MultiSelect[T]) printOptions(w io.Writer) []int {
+	return printChoices(w, m.options.val, func(option Option[T]) string {
 		if option.selected {
-			sb.WriteString(styles.SelectedOption.Render(fmt.Sprintf("%d. %s %s", i+1, "✓", option.Key)))
-		} else {
-			sb.WriteString(fmt.Sprintf("%d. %s %s", i+1, " ", option.Key))
+			return "[x] "
 		}
-		sb.WriteString("\n")
-	}
-
-	fmt.Println(sb.String())
+		return "[ ] "
+	})
 }
 
 // setFilter sets the filter of the select field.
@@ -622,17 +762,17 @@ func (m *MultiSelect[T]) setFilter(filter bool) {
 	m.keymap.ClearFilter.SetEnabled(!filter && m.filter.Value() != "")
 }
 
-// filterFunc returns true if the option matches the filter.
-func (m *MultiSelect[T]) filterFunc(option string) bool {
-	// XXX: remove diacritics or allow customization of filter function.
-	return strings.Contains(strings.ToLower(option), strings.ToLower(m.filter.Value()))
-}
-
 // setSelectAllHelp enables the appropriate select all or select none keybinding.
 func (m *MultiSelect[T]) setSelectAllHelp() {
 	if m.limit <= 0 {
+		var choices int
+		for _, option := range m.filteredOptions {
+			if option.isChoice() {
+				choices++
+			}
+		}
 		noneSelected := m.numFilteredSelected() <= 0
-		allSelected := m.numFilteredSelected() > 0 && m.numFilteredSelected() < len(m.filteredOptions)
+		allSelected := m.numFilteredSelected() > 0 && m.numFilteredSelected() < choices
 		selectAll := noneSelected || allSelected
 		m.keymap.SelectAll.SetEnabled(selectAll)
 		m.keymap.SelectNone.SetEnabled(!selectAll)
@@ -642,57 +782,60 @@ func (m *MultiSelect[T]) setSelectAllHelp() {
 // Run runs the multi-select field.
 func (m *MultiSelect[T]) Run() error {
 	if m.accessible {
-		return m.runAccessible()
+		return m.RunAccessible(os.Stdout, os.Stdin)
 	}
 	return Run(m)
 }
 
-// runAccessible() runs the multi-select field in accessible mode.
-func (m *MultiSelect[T]) runAccessible() error {
-	m.printOptions()
-	styles := m.activeStyles()
+// RunAccessible runs the multi-select field in accessible mode, reading
+// answers from r and writing prompts to w.
+func (m *MultiSelect[T]) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	rules := []string{"Enter the number of an option to select or deselect it, or 0 when done."}
+	if m.limit > 0 {
+		rules = append(rules, fmt.Sprintf("Select up to %d options.", m.limit))
+	}
+	a.announce(m.title.val, plainDescription(m.theme, m.description.val), rules...)
+	choices := m.printOptions(w)
 
-	var choice int
 	for {
-		fmt.Printf("Select up to %d options. 0 to continue.\n", m.limit)
-
-		choice = accessibility.PromptInt("Select: ", 0, len(m.options.val))
+		input, err := a.prompt("Select", "0", validChoice(0, len(choices)))
+		if err != nil {
+			return err
+		}
+		choice, _ := strconv.Atoi(input)
 		if choice == 0 {
 			m.updateValue()
-			err := m.validate(m.accessor.Get())
-			if err != nil {
-				fmt.Println(err)
+			if err := withAsyncValidation(m.validate, m.validateAsync)(m.accessor.Get()); err != nil {
+				a.reject(err)
 				continue
 			}
 			break
 		}
 
-		if !m.options.val[choice-1].selected && m.limit > 0 && m.numSelected() >= m.limit {
-			fmt.Printf("You can't select more than %d options.\n", m.limit)
+		option := &m.options.val[choices[choice-1]]
+		if option.disabled {
+			a.reject(errOptionDisabled(*option))
 			continue
 		}
-		m.options.val[choice-1].selected = !m.options.val[choice-1].selected
-		if m.options.val[choice-1].selected {
-			fmt.Printf("Selected: %s\n\n", m.options.val[choice-1].Key)
-		} else {
-			fmt.Printf("Deselected: %s\n\n", m.options.val[choice-1].Key)
+		if !option.selected && m.limit > 0 && m.numSelected() >= m.limit {
+			a.reject(fmt.Errorf("you can't select more than %d options", m.limit))
+			continue
 		}
-
-		m.printOptions()
-	}
-
-	var values []string
-
-	value := m.accessor.Get()
-	for _, option := range m.options.val {
+		option.selected = !option.selected
 		if option.selected {
-			value = append(value, option.Value)
-			values = append(values, option.Key)
+			fmt.Fprintf(w, "Selected: %s\n", plain(option.Key))
+		} else {
+			fmt.Fprintf(w, "Deselected: %s\n", plain(option.Key))
 		}
+		m.printOptions(w)
 	}
-	m.accessor.Set(value)
 
-	fmt.Println(styles.SelectedOption.Render("Selected:", strings.Join(values, ", ")+"\n"))
+	_, answer := m.summarize()
+	if answer == "" {
+		answer = "none"
+	}
+	a.answer(answer)
 	return nil
 }
 
@@ -719,6 +862,8 @@ func (m *MultiSelect[T]) WithKeyMap(k *KeyMap) Field {
 		m.keymap.ClearFilter.SetEnabled(false)
 		m.keymap.SetFilter.SetEnabled(false)
 	}
+	m.keymap.PreviewUp.SetEnabled(m.preview.enabled())
+	m.keymap.PreviewDown.SetEnabled(m.preview.enabled())
 	return m
 }
 
@@ -731,6 +876,7 @@ func (m *MultiSelect[T]) WithAccessible(accessible bool) Field {
 // WithWidth sets the width of the multi-select field.
 func (m *MultiSelect[T]) WithWidth(width int) Field {
 	m.width = width
+	m.updateViewportHeight()
 	return m
 }
 
@@ -761,3 +907,70 @@ func (m *MultiSelect[T]) GetKey() string {
 func (m *MultiSelect[T]) GetValue() any {
 	return m.accessor.Get()
 }
+
+// summarize returns the title and the keys of the selected options for the
+// review page.
+func (m *MultiSelect[T]) summarize() (string, string) {
+	var keys []string
+	for _, option := range m.options.val {
+		if option.selected {
+			keys = append(keys, option.Key)
+		}
+	}
+	return m.title.val, strings.Join(keys, ", ")
+}
+
+// HoveredKey returns the key of the option under the cursor, or an empty
+// string if there is none.
+func (m *MultiSelect[T]) HoveredKey() string {
+	if m.cursor < 0 || m.cursor >= len(m.filteredOptions) {
+		return ""
+	}
+	return m.filteredOptions[m.cursor].Key
+}
+
+// setValue sets the value of the field from an answer, matching options by
+// value or by key.
+func (m *MultiSelect[T]) setValue(value any) error {
+	if v, ok := value.([]T); ok {
+		value = toAnySlice(v)
+	}
+
+	selected := make(map[int]bool)
+	values := make([]T, 0)
+	for _, answer := range answerList(value) {
+		i := findOption(m.options.val, answer)
+		if i < 0 {
+			return fmt.Errorf("%v is not one of the options", answer)
+		}
+		if option := m.options.val[i]; option.disabled && !option.selected {
+			return errOptionDisabled(option)
+		}
+		if !selected[i] {
+			selected[i] = true
+			values = append(values, m.options.val[i].Value)
+		}
+	}
+	if m.limit > 0 && len(values) > m.limit {
+		return fmt.Errorf("at most %d options can be selected", m.limit)
+	}
+	if err := withAsyncValidation(m.validate, m.validateAsync)(values); err != nil {
+		return err
+	}
+	for i := range m.options.val {
+		m.options.val[i].selected = selected[i]
+	}
+	m.filter.SetValue("")
+	m.filteredOptions = m.options.val
+	m.accessor.Set(values)
+	return nil
+}
+
+// toAnySlice converts a typed slice to a slice of any.
+func toAnySlice[T any](values []T) []any {
+	s := make([]any, len(values))
+	for i, v := range values {
+		s[i] = v
+	}
+	return s
+}
@@ -2,10 +2,13 @@ package huh
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // Note is a note field.
@@ -14,6 +17,8 @@ import (
 // provide context around a different field. Generally, the notes are not
 // interacted with unless the note has a next button `Next(true)`.
 type Note struct {
+	fieldHide
+
 	id int
 
 	title       Eval[string]
@@ -72,7 +77,9 @@ func (n *Note) TitleFunc(f func() string, bindings any) *Note {
 
 // Description sets the note field's description.
 //
-// This description will be static, for dynamic descriptions use `DescriptionFunc`.
+// The description is rendered as Markdown, with headings, lists, links,
+// tables and code. This description will be static, for dynamic descriptions
+// use `DescriptionFunc`.
 func (n *Note) Description(description string) *Note {
 	n.description.val = description
 	n.description.fn = nil
@@ -142,7 +149,21 @@ func (n *Note) Blur() tea.Cmd {
 func (n *Note) Error() error { return nil }
 
 // Skip returns whether the note should be skipped or should be blocking.
-func (n *Note) Skip() bool { return n.skip }
+// Hidden notes are skipped.
+func (n *Note) Skip() bool { return n.skip || n.hidden() }
+
+// WithHideFunc sets the function that checks if the note should be hidden.
+func (n *Note) WithHideFunc(hideFunc func() bool) *Note {
+	n.setHideFunc(hideFunc)
+	return n
+}
+
+// WithShowWhen sets the function that checks if the note should be shown.
+// It is the opposite of WithHideFunc.
+func (n *Note) WithShowWhen(showFunc func() bool) *Note {
+	n.setShowWhen(showFunc)
+	return n
+}
 
 // Zoom returns whether the note should be zoomed.
 func (n *Note) Zoom() bool { return false }
@@ -222,33 +243,44 @@ func (n *Note) View() string {
 	if n.title.val != "" || n.title.fn != nil {
 		sb.WriteString(styles.NoteTitle.Render(n.title.val))
 	}
+	description := n.descriptionView()
 	if n.description.val != "" || n.description.fn != nil {
 		sb.WriteString("\n")
-		sb.WriteString(render(n.description.val))
+		sb.WriteString(description)
 	}
 	if n.showNextButton {
+		if description != "" {
+			sb.WriteString("\n\n")
+		}
 		sb.WriteString(styles.Next.Render(n.nextLabel))
 	}
 	return styles.Card.Height(n.height).Render(sb.String())
 }
 
+// descriptionView renders the description of the note as Markdown, wrapped
+// to the width of the note.
+func (n *Note) descriptionView() string {
+	styles := n.activeStyles()
+	width := 0
+	if n.width > 0 {
+		width = max(n.width-styles.Card.GetHorizontalFrameSize(), 1)
+	}
+	return renderMarkdown(n.description.val, width, &styles.Markdown, lipgloss.NewStyle())
+}
+
 // Run runs the note field.
 func (n *Note) Run() error {
 	if n.accessible {
-		return n.runAccessible()
+		return n.RunAccessible(os.Stdout, os.Stdin)
 	}
 	return Run(n)
 }
 
-// runAccessible runs an accessible note field.
-func (n *Note) runAccessible() error {
-	if n.title.val != "" {
-		fmt.Println(n.title.val)
-		fmt.Println()
-	}
-
-	fmt.Println(n.description.val)
-	fmt.Println()
+// RunAccessible runs an accessible note field, writing it to w.
+func (n *Note) RunAccessible(w io.Writer, _ io.Reader) error {
+	a := newAccessibleRenderer(w, nil)
+	a.announce(n.title.val, plainMarkdown(n.description.val))
+	fmt.Fprintln(w)
 	return nil
 }
 
@@ -303,61 +335,3 @@ func (n *Note) GetValue() any { return nil }
 
 // GetKey satisfies the Field interface, notes do not have keys.
 func (n *Note) GetKey() string { return "" }
-
-func render(input string) string {
-	var result strings.Builder
-	var italic, bold, codeblock bool
-	var escape bool
-
-	for _, char := range input {
-		if escape || codeblock {
-			result.WriteRune(char)
-			escape = false
-			continue
-		}
-		switch char {
-		case '\\':
-			escape = true
-		case '_':
-			if !italic {
-				result.WriteString("\033[3m")
-				italic = true
-			} else {
-				result.WriteString("\033[23m")
-				italic = false
-			}
-		case '*':
-			if !bold {
-				result.WriteString("\033[1m")
-				bold = true
-			} else {
-				result.WriteString("\033[22m")
-				bold = false
-			}
-		case '`':
-			if !codeblock {
-				result.WriteString("\033[0;37;40m")
-				result.WriteString(" ")
-				codeblock = true
-			} else {
-				result.WriteString(" ")
-				result.WriteString("\033[0m")
-				codeblock = false
-
-				if bold {
-					result.WriteString("\033[1m")
-				}
-				if italic {
-					result.WriteString("\033[3m")
-				}
-			}
-		default:
-			result.WriteRune(char)
-		}
-	}
-
-	// Reset any open formatting
-	result.WriteString("\033[0m")
-
-	return result.String()
-}
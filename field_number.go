@@ -0,0 +1,616 @@
+package huh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Number is a number field.
+//
+// The number field is an input field for integers or floating point numbers.
+// Its value can be typed, or incremented and decremented by a step with the
+// up and down keys, and is kept within optional bounds.
+//
+// Numbers are parsed according to the locale set in the environment, so that
+// "1.234,5" is understood as 1234.5 in a German locale. See Separators.
+type Number[T int | int64 | float64] struct {
+	fieldHide
+
+	accessor Accessor[T]
+	input    *Input
+
+	minValue T
+	maxValue T
+	hasMin   bool
+	hasMax   bool
+	step     T
+
+	decimal rune
+	group   rune
+
+	validate func(T) error
+
+	accessible bool
+	keymap     NumberKeyMap
+}
+
+// NewNumber creates a new number field.
+//
+// The number field is an input field for integers or floating point numbers.
+// Its value can be typed, or incremented and decremented by a step with the
+// up and down keys, and is kept within optional bounds.
+func NewNumber[T int | int64 | float64]() *Number[T] {
+	decimal, group := localeSeparators()
+	n := &Number[T]{
+		accessor: &EmbeddedAccessor[T]{},
+		input:    NewInput(),
+		step:     1,
+		decimal:  decimal,
+		group:    group,
+		validate: func(T) error { return nil },
+	}
+	n.input.Validate(n.validateText)
+	n.input.textinput.SetValue(n.format(0))
+	return n
+}
+
+// Value sets the value of the number field.
+func (n *Number[T]) Value(value *T) *Number[T] {
+	return n.Accessor(NewPointerAccessor(value))
+}
+
+// Accessor sets the accessor of the number field.
+func (n *Number[T]) Accessor(accessor Accessor[T]) *Number[T] {
+	n.accessor = accessor
+	n.input.textinput.SetValue(n.format(accessor.Get()))
+	n.input.accessor.Set(n.input.textinput.Value())
+	return n
+}
+
+// Key sets the key of the number field.
+func (n *Number[T]) Key(key string) *Number[T] {
+	n.input.Key(key)
+	return n
+}
+
+// Title sets the title of the number field.
+func (n *Number[T]) Title(title string) *Number[T] {
+	n.input.Title(title)
+	return n
+}
+
+// TitleFunc sets the title func of the number field.
+func (n *Number[T]) TitleFunc(f func() string, bindings any) *Number[T] {
+	n.input.TitleFunc(f, bindings)
+	return n
+}
+
+// Description sets the description of the number field.
+func (n *Number[T]) Description(description string) *Number[T] {
+	n.input.Description(description)
+	return n
+}
+
+// DescriptionFunc sets the description func of the number field.
+func (n *Number[T]) DescriptionFunc(f func() string, bindings any) *Number[T] {
+	n.input.DescriptionFunc(f, bindings)
+	return n
+}
+
+// Placeholder sets the placeholder of the number field.
+func (n *Number[T]) Placeholder(str string) *Number[T] {
+	n.input.Placeholder(str)
+	return n
+}
+
+// Prompt sets the prompt of the number field.
+func (n *Number[T]) Prompt(prompt string) *Number[T] {
+	n.input.Prompt(prompt)
+	return n
+}
+
+// Inline sets whether the title and number should be on the same line.
+func (n *Number[T]) Inline(inline bool) *Number[T] {
+	n.input.Inline(inline)
+	return n
+}
+
+// Min sets the minimum value of the number field.
+func (n *Number[T]) Min(v T) *Number[T] {
+	n.minValue = v
+	n.hasMin = true
+	return n
+}
+
+// Max sets the maximum value of the number field.
+func (n *Number[T]) Max(v T) *Number[T] {
+	n.maxValue = v
+	n.hasMax = true
+	return n
+}
+
+// Step sets the amount by which the up and down keys change the value of the
+// number field. It defaults to 1.
+func (n *Number[T]) Step(step T) *Number[T] {
+	if step > 0 {
+		n.step = step
+	}
+	return n
+}
+
+// Separators sets the decimal and digit group separators used to parse and
+// display numbers, overriding the ones of the locale set in the environment.
+func (n *Number[T]) Separators(decimal, group rune) *Number[T] {
+	n.decimal = decimal
+	n.group = group
+	n.input.textinput.SetValue(n.format(n.accessor.Get()))
+	return n
+}
+
+// Validate sets the validation function of the number field.
+//
+// The function is only called with numbers within the bounds of the field.
+func (n *Number[T]) Validate(validate func(T) error) *Number[T] {
+	n.validate = validate
+	return n
+}
+
+// ValidateAsync sets the asynchronous validation function of the number
+// field.
+//
+// The function runs as a command once the user stops typing, after the
+// synchronous validation passes, and its context is cancelled when the value
+// changes again. The field can't be submitted until the validation resolves.
+func (n *Number[T]) ValidateAsync(validate func(context.Context, T) error) *Number[T] {
+	n.input.ValidateAsync(func(ctx context.Context, s string) error {
+		v, err := n.parse(s)
+		if err != nil {
+			return err
+		}
+		return validate(ctx, v)
+	})
+	return n
+}
+
+// Error returns the error of the number field.
+func (n *Number[T]) Error() error { return n.input.Error() }
+
+// Skip returns whether the number field should be skipped or should be blocking.
+// Hidden number fields are skipped.
+func (n *Number[T]) Skip() bool { return n.hidden() }
+
+// WithHideFunc sets the function that checks if the number field should be hidden.
+func (n *Number[T]) WithHideFunc(hideFunc func() bool) *Number[T] {
+	n.setHideFunc(hideFunc)
+	return n
+}
+
+// WithShowWhen sets the function that checks if the number field should be shown.
+// It is the opposite of WithHideFunc.
+func (n *Number[T]) WithShowWhen(showFunc func() bool) *Number[T] {
+	n.setShowWhen(showFunc)
+	return n
+}
+
+// Zoom returns whether the number field should be zoomed.
+func (*Number[T]) Zoom() bool { return false }
+
+// Focus focuses the number field.
+func (n *Number[T]) Focus() tea.Cmd {
+	return n.input.Focus()
+}
+
+// Blur blurs the number field.
+func (n *Number[T]) Blur() tea.Cmd {
+	cmd := n.input.Blur()
+	n.syncValue()
+	return cmd
+}
+
+// KeyBinds returns the help message for the number field.
+func (n *Number[T]) KeyBinds() []key.Binding {
+	return []key.Binding{n.keymap.Increment, n.keymap.Decrement, n.keymap.Prev, n.keymap.Submit, n.keymap.Next}
+}
+
+// Init initializes the number field.
+func (n *Number[T]) Init() tea.Cmd {
+	return n.input.Init()
+}
+
+// Update updates the number field.
+func (n *Number[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch {
+		case key.Matches(msg, n.keymap.Increment):
+			return n, n.increment(n.step)
+		case key.Matches(msg, n.keymap.Decrement):
+			return n, n.increment(-n.step)
+		}
+	}
+
+	_, cmd := n.input.Update(msg)
+	n.syncValue()
+	return n, cmd
+}
+
+// increment changes the value of the number field by delta, keeping it within
+// bounds.
+func (n *Number[T]) increment(delta T) tea.Cmd {
+	v, err := n.parse(n.input.textinput.Value())
+	if err != nil {
+		v = n.accessor.Get()
+	}
+	v = n.clamp(n.round(v + delta))
+
+	n.input.err = nil
+	n.input.textinput.SetValue(n.format(v))
+	n.input.textinput.CursorEnd()
+	cmd := n.input.textChanged()
+	n.syncValue()
+	return cmd
+}
+
+// syncValue sets the value of the accessor from the text of the input, when
+// it is a valid number.
+func (n *Number[T]) syncValue() {
+	v, err := n.parse(n.input.textinput.Value())
+	if err != nil || n.checkBounds(v) != nil {
+		return
+	}
+	n.accessor.Set(v)
+}
+
+// View renders the number field.
+func (n *Number[T]) View() string {
+	return n.input.View()
+}
+
+// Run runs the number field.
+func (n *Number[T]) Run() error {
+	if n.accessible {
+		return n.RunAccessible(os.Stdout, os.Stdin)
+	}
+	return Run(n)
+}
+
+// RunAccessible runs the number field in accessible mode, reading answers from
+// r and writing prompts to w.
+//
+// The number is read with the same range checking as Prompter.PromptFloat,
+// parsed with the separators of the field, then validated, re-prompting until
+// it is valid.
+func (n *Number[T]) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	kind := "number"
+	if !isFloat[T]() {
+		kind = "whole number"
+	}
+	rule := "Enter a " + kind + "."
+	switch {
+	case n.hasMin && n.hasMax:
+		rule = fmt.Sprintf("Enter a %s from %s to %s.", kind, n.format(n.minValue), n.format(n.maxValue))
+	case n.hasMin:
+		rule = fmt.Sprintf("Enter a %s of at least %s.", kind, n.format(n.minValue))
+	case n.hasMax:
+		rule = fmt.Sprintf("Enter a %s of at most %s.", kind, n.format(n.maxValue))
+	}
+	a.announce(n.input.title.val, plainDescription(n.input.theme, n.input.description.val), rule)
+
+	low, high := math.Inf(-1), math.Inf(1)
+	if n.hasMin {
+		low = float64(n.minValue)
+	}
+	if n.hasMax {
+		high = float64(n.maxValue)
+	}
+	var v T
+	parse := func(s string) (float64, error) {
+		parsed, err := n.parse(s)
+		if err != nil {
+			return 0, err
+		}
+		v = parsed
+		return float64(parsed), nil
+	}
+	validate := func() error {
+		if err := n.validate(v); err != nil {
+			return err
+		}
+		return n.input.validateAsync.run(n.format(v))
+	}
+	for {
+		if _, err := a.promptNumber("Number", n.input.textinput.Value(), low, high, parse); err != nil {
+			return err
+		}
+		if err := validate(); err != nil {
+			a.reject(err)
+			continue
+		}
+		break
+	}
+	n.accessor.Set(v)
+	n.input.textinput.SetValue(n.format(v))
+	a.answer(n.format(v))
+	return nil
+}
+
+// WithKeyMap sets the keymap on a number field.
+func (n *Number[T]) WithKeyMap(k *KeyMap) Field {
+	n.keymap = k.Number
+	n.input.WithKeyMap(&KeyMap{Input: InputKeyMap{
+		Next:   k.Number.Next,
+		Prev:   k.Number.Prev,
+		Submit: k.Number.Submit,
+	}})
+	return n
+}
+
+// WithAccessible sets the accessible mode of the number field.
+func (n *Number[T]) WithAccessible(accessible bool) Field {
+	n.accessible = accessible
+	n.input.WithAccessible(accessible)
+	return n
+}
+
+// WithTheme sets the theme of the number field.
+func (n *Number[T]) WithTheme(theme *Theme) Field {
+	n.input.WithTheme(theme)
+	return n
+}
+
+// WithWidth sets the width of the number field.
+func (n *Number[T]) WithWidth(width int) Field {
+	n.input.WithWidth(width)
+	return n
+}
+
+// WithHeight sets the height of the number field.
+func (n *Number[T]) WithHeight(height int) Field {
+	n.input.WithHeight(height)
+	return n
+}
+
+// WithPosition sets the position of the number field.
+func (n *Number[T]) WithPosition(p FieldPosition) Field {
+	n.input.WithPosition(p)
+	n.keymap.Prev.SetEnabled(!p.IsFirst())
+	n.keymap.Next.SetEnabled(!p.IsLast())
+	n.keymap.Submit.SetEnabled(p.IsLast())
+	return n
+}
+
+// GetKey returns the key of the field.
+func (n *Number[T]) GetKey() string { return n.input.GetKey() }
+
+// GetValue returns the value of the field.
+func (n *Number[T]) GetValue() any {
+	return n.accessor.Get()
+}
+
+// summarize returns the title and the formatted number for the review page.
+func (n *Number[T]) summarize() (string, string) {
+	return n.input.title.val, n.format(n.accessor.Get())
+}
+
+// setValue sets the value of the field from an answer.
+//
+// Strings are parsed as Go number literals, regardless of the locale, since
+// answers are meant to be machine readable.
+func (n *Number[T]) setValue(value any) error {
+	var f float64
+	switch v := value.(type) {
+	case int:
+		f = float64(v)
+	case int64:
+		f = float64(v)
+	case float64:
+		f = v
+	default:
+		var err error
+		f, err = strconv.ParseFloat(strings.TrimSpace(answerString(value)), 64)
+		if err != nil {
+			return fmt.Errorf("%v is not a number", value)
+		}
+	}
+	if !isFloat[T]() && f != math.Trunc(f) {
+		return fmt.Errorf("%v is not a whole number", value)
+	}
+
+	v := T(f)
+	if err := n.validateValue(v); err != nil {
+		return err
+	}
+	if err := n.input.validateAsync.run(n.format(v)); err != nil {
+		return err
+	}
+	n.accessor.Set(v)
+	n.input.textinput.SetValue(n.format(v))
+	n.input.accessor.Set(n.input.textinput.Value())
+	return nil
+}
+
+// validateText validates the text typed in the number field.
+func (n *Number[T]) validateText(s string) error {
+	v, err := n.parse(s)
+	if err != nil {
+		return err
+	}
+	return n.validateValue(v)
+}
+
+// validateValue validates a number against the bounds and the validation
+// function of the field.
+func (n *Number[T]) validateValue(v T) error {
+	if err := n.checkBounds(v); err != nil {
+		return err
+	}
+	return n.validate(v)
+}
+
+// checkBounds returns an error if the number is out of bounds.
+func (n *Number[T]) checkBounds(v T) error {
+	if n.hasMin && v < n.minValue {
+		return fmt.Errorf("must be at least %s", n.format(n.minValue))
+	}
+	if n.hasMax && v > n.maxValue {
+		return fmt.Errorf("must be at most %s", n.format(n.maxValue))
+	}
+	return nil
+}
+
+// clamp keeps the number within the bounds of the field.
+func (n *Number[T]) clamp(v T) T {
+	if n.hasMin && v < n.minValue {
+		v = n.minValue
+	}
+	if n.hasMax && v > n.maxValue {
+		v = n.maxValue
+	}
+	return v
+}
+
+// round rounds floating point numbers to the precision of the step, so that
+// stepping by 0.1 doesn't accumulate errors.
+func (n *Number[T]) round(v T) T {
+	if !isFloat[T]() {
+		return v
+	}
+	step := strconv.FormatFloat(float64(n.step), 'f', -1, 64)
+	_, decimals, _ := strings.Cut(step, ".")
+	scale := math.Pow(10, float64(len(decimals)))
+	return T(math.Round(float64(v)*scale) / scale)
+}
+
+// parse parses a number written with the separators of the field. Digit
+// group separators, or spaces, are only allowed between groups of three
+// digits in the integer part, as in "1,234,567".
+func (n *Number[T]) parse(s string) (T, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New("enter a number")
+	}
+
+	var (
+		sb      strings.Builder
+		integer = true // in the integer part
+		grouped bool   // a group separator was seen
+		digits  int    // digits since the last group separator
+	)
+	// endInteger ends the integer part, whose last group must be complete.
+	endInteger := func() bool {
+		integer = false
+		return !grouped || digits == 3
+	}
+	for _, r := range s {
+		switch {
+		case n.isGroupSeparator(r):
+			if !integer || digits == 0 || digits > 3 || grouped && digits != 3 {
+				return 0, fmt.Errorf("%q is not a number", s)
+			}
+			grouped, digits = true, 0
+			continue
+		case integer && r >= '0' && r <= '9':
+			digits++
+		case integer && (r == n.decimal || digits > 0):
+			// The integer part ends at the decimal separator or, such as
+			// at an exponent, at the first other character after it.
+			if !endInteger() {
+				return 0, fmt.Errorf("%q is not a number", s)
+			}
+		}
+		if r == n.decimal {
+			r = '.'
+		}
+		sb.WriteRune(r)
+	}
+	if integer && !endInteger() {
+		return 0, fmt.Errorf("%q is not a number", s)
+	}
+
+	if isFloat[T]() {
+		f, err := strconv.ParseFloat(sb.String(), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, fmt.Errorf("%q is not a number", s)
+		}
+		return T(f), nil
+	}
+
+	i, err := strconv.ParseInt(sb.String(), 10, 64)
+	if err != nil {
+		// Whole numbers may also be written with an exponent, as in "1e5".
+		f, ferr := strconv.ParseFloat(sb.String(), 64)
+		switch {
+		case ferr != nil || math.IsNaN(f):
+			return 0, fmt.Errorf("%q is not a number", s)
+		case f != math.Trunc(f):
+			return 0, fmt.Errorf("%q is not a whole number", s)
+		case f < math.MinInt64 || f >= math.MaxInt64:
+			return 0, fmt.Errorf("%q is too large", s)
+		}
+		i = int64(f)
+	}
+	return T(i), nil
+}
+
+// isGroupSeparator returns whether r separates groups of digits, either the
+// group separator of the field or a space.
+func (n *Number[T]) isGroupSeparator(r rune) bool {
+	switch r {
+	case n.group, ' ', '\u00a0', '\u202f':
+		return true
+	}
+	return false
+}
+
+// format formats a number with the decimal separator of the field.
+func (n *Number[T]) format(v T) string {
+	if !isFloat[T]() {
+		return strconv.FormatInt(int64(v), 10)
+	}
+	s := strconv.FormatFloat(float64(v), 'f', -1, 64)
+	return strings.Replace(s, ".", string(n.decimal), 1)
+}
+
+// isFloat returns whether T is a floating point type.
+func isFloat[T int | int64 | float64]() bool {
+	var zero T
+	_, ok := any(zero).(float64)
+	return ok
+}
+
+// commaDecimalLanguages are the languages whose locales write numbers with a
+// decimal comma.
+var commaDecimalLanguages = map[string]bool{
+	"bg": true, "cs": true, "da": true, "de": true, "el": true, "es": true,
+	"et": true, "fi": true, "fr": true, "hr": true, "hu": true, "id": true,
+	"it": true, "lt": true, "lv": true, "nb": true, "nl": true, "nn": true,
+	"no": true, "pl": true, "pt": true, "ro": true, "ru": true, "sk": true,
+	"sl": true, "sr": true, "sv": true, "tr": true, "uk": true, "vi": true,
+}
+
+// localeSeparators returns the decimal and digit group separators of the
+// locale set in the environment, defaulting to a dot and a comma.
+func localeSeparators() (decimal, group rune) {
+	for _, env := range []string{"LC_ALL", "LC_NUMERIC", "LANG"} {
+		locale := os.Getenv(env)
+		if locale == "" {
+			continue
+		}
+		lang, _, _ := strings.Cut(strings.ToLower(locale), "_")
+		lang, _, _ = strings.Cut(lang, ".")
+		if commaDecimalLanguages[lang] {
+			return ',', '.'
+		}
+		break
+	}
+	return '.', ','
+}
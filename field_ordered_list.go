@@ -0,0 +1,679 @@
+package huh
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// OrderedList is a field ranking options, such as the order in which to
+// deploy to a set of targets. The option under the cursor is moved up and
+// down with shift+up and shift+down.
+//
+// Pinned options keep their position, and other options move past them. With
+// a limit, only the first options are ranked and make up the value, the others
+// are listed below them and can be moved into the ranking.
+type OrderedList[T comparable] struct {
+	fieldHide
+
+	id       int
+	accessor Accessor[[]T]
+	key      string
+
+	viewport viewport.Model
+
+	title       string
+	description string
+	original    []Option[T]
+	options     []Option[T]
+	pinned      map[T]bool
+	limit       int
+
+	validate func([]T) error
+	err      error
+
+	cursor  int
+	focused bool
+
+	width      int
+	height     int
+	accessible bool
+	theme      *Theme
+	keymap     OrderedListKeyMap
+}
+
+// NewOrderedList creates a new ordered list field.
+func NewOrderedList[T comparable]() *OrderedList[T] {
+	return &OrderedList[T]{
+		id:       nextID(),
+		accessor: &EmbeddedAccessor[[]T]{},
+		pinned:   make(map[T]bool),
+		validate: func([]T) error { return nil },
+	}
+}
+
+// Options sets the options to rank, in their initial order. Group headers and
+// separators are left out.
+func (o *OrderedList[T]) Options(options ...Option[T]) *OrderedList[T] {
+	o.original, o.options = nil, nil
+	for _, option := range options {
+		if option.isChoice() {
+			o.original = append(o.original, option)
+		}
+	}
+	o.cursor = 0
+	o.arrange(o.accessor.Get())
+	return o
+}
+
+// Value sets the value of the ordered list field. Options in the value are
+// ranked first, in the same order.
+func (o *OrderedList[T]) Value(value *[]T) *OrderedList[T] {
+	return o.Accessor(NewPointerAccessor(value))
+}
+
+// Accessor sets the accessor of the ordered list field.
+func (o *OrderedList[T]) Accessor(accessor Accessor[[]T]) *OrderedList[T] {
+	o.accessor = accessor
+	o.arrange(accessor.Get())
+	return o
+}
+
+// Pinned pins the options with the given values to their position in the
+// options, such as a canary that must always be deployed to first.
+func (o *OrderedList[T]) Pinned(values ...T) *OrderedList[T] {
+	o.pinned = make(map[T]bool)
+	for _, v := range values {
+		o.pinned[v] = true
+	}
+	o.arrange(o.order())
+	return o
+}
+
+// Limit sets how many options are ranked. Only the first options make up the
+// value, the others are listed below them. All options are ranked by default.
+func (o *OrderedList[T]) Limit(limit int) *OrderedList[T] {
+	o.limit = limit
+	o.updateValue()
+	return o
+}
+
+// Key sets the key of the ordered list field which can be used to retrieve
+// the value after submission.
+func (o *OrderedList[T]) Key(key string) *OrderedList[T] {
+	o.key = key
+	return o
+}
+
+// Title sets the title of the ordered list field.
+func (o *OrderedList[T]) Title(title string) *OrderedList[T] {
+	o.title = title
+	return o
+}
+
+// Description sets the description of the ordered list field.
+func (o *OrderedList[T]) Description(description string) *OrderedList[T] {
+	o.description = description
+	return o
+}
+
+// Height sets the height of the ordered list field. If the options exceed
+// the height, the list becomes scrollable.
+func (o *OrderedList[T]) Height(height int) *OrderedList[T] {
+	o.height = height
+	o.updateViewportHeight()
+	return o
+}
+
+// Validate sets the validation function of the ordered list field, called
+// with the ranked values.
+func (o *OrderedList[T]) Validate(validate func([]T) error) *OrderedList[T] {
+	o.validate = validate
+	return o
+}
+
+// Error returns the error of the ordered list field.
+func (o *OrderedList[T]) Error() error { return o.err }
+
+// Skip returns whether the ordered list should be skipped or should be
+// blocking. Hidden ordered lists are skipped.
+func (o *OrderedList[T]) Skip() bool { return o.hidden() }
+
+// WithHideFunc sets the function that checks if the ordered list should be
+// hidden.
+func (o *OrderedList[T]) WithHideFunc(hideFunc func() bool) *OrderedList[T] {
+	o.setHideFunc(hideFunc)
+	return o
+}
+
+// WithShowWhen sets the function that checks if the ordered list should be
+// shown. It is the opposite of WithHideFunc.
+func (o *OrderedList[T]) WithShowWhen(showFunc func() bool) *OrderedList[T] {
+	o.setShowWhen(showFunc)
+	return o
+}
+
+// Zoom returns whether the ordered list should be zoomed.
+func (*OrderedList[T]) Zoom() bool { return false }
+
+// Focus focuses the ordered list field.
+func (o *OrderedList[T]) Focus() tea.Cmd {
+	o.focused = true
+	return nil
+}
+
+// Blur blurs the ordered list field.
+func (o *OrderedList[T]) Blur() tea.Cmd {
+	o.focused = false
+	o.err = o.validate(o.accessor.Get())
+	return nil
+}
+
+// KeyBinds returns the help keybindings for the ordered list field.
+func (o *OrderedList[T]) KeyBinds() []key.Binding {
+	return []key.Binding{
+		o.keymap.Up,
+		o.keymap.Down,
+		o.keymap.MoveUp,
+		o.keymap.MoveDown,
+		o.keymap.Prev,
+		o.keymap.Next,
+		o.keymap.Submit,
+	}
+}
+
+// Init initializes the ordered list field.
+func (o *OrderedList[T]) Init() tea.Cmd {
+	return nil
+}
+
+// Update updates the ordered list field.
+func (o *OrderedList[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	o.updateViewportHeight()
+
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		o.err = nil
+		switch {
+		case key.Matches(msg, o.keymap.MoveUp):
+			o.move(-1)
+		case key.Matches(msg, o.keymap.MoveDown):
+			o.move(1)
+		case key.Matches(msg, o.keymap.Up):
+			o.moveCursor(o.cursor - 1)
+		case key.Matches(msg, o.keymap.Down):
+			o.moveCursor(o.cursor + 1)
+		case key.Matches(msg, o.keymap.GotoTop):
+			o.moveCursor(0)
+		case key.Matches(msg, o.keymap.GotoBottom):
+			o.moveCursor(len(o.options) - 1)
+		case key.Matches(msg, o.keymap.Prev):
+			o.err = o.validate(o.accessor.Get())
+			if o.err != nil {
+				return o, nil
+			}
+			return o, PrevField
+		case key.Matches(msg, o.keymap.Next, o.keymap.Submit):
+			o.err = o.validate(o.accessor.Get())
+			if o.err != nil {
+				return o, nil
+			}
+			return o, NextField
+		}
+	}
+
+	return o, nil
+}
+
+// moveCursor moves the cursor to the given option, wrapping around the ends
+// of the list.
+func (o *OrderedList[T]) moveCursor(i int) {
+	if len(o.options) == 0 {
+		return
+	}
+	switch {
+	case i < 0:
+		i = len(o.options) - 1
+	case i >= len(o.options):
+		i = 0
+	}
+	o.cursor = i
+	o.keepInView()
+}
+
+// move moves the option under the cursor up or down, past pinned options.
+// Pinned options don't move.
+func (o *OrderedList[T]) move(dir int) {
+	if o.cursor < 0 || o.cursor >= len(o.options) || o.isPinned(o.cursor) {
+		return
+	}
+	j := o.cursor + dir
+	for j >= 0 && j < len(o.options) && o.isPinned(j) {
+		j += dir
+	}
+	if j < 0 || j >= len(o.options) {
+		return
+	}
+	// Only pinned options are between the two, so swapping them keeps the
+	// pinned options in place.
+	o.options[o.cursor], o.options[j] = o.options[j], o.options[o.cursor]
+	o.cursor = j
+	o.keepInView()
+	o.updateValue()
+}
+
+// isPinned returns whether the option at index i is pinned.
+func (o *OrderedList[T]) isPinned(i int) bool {
+	return o.pinned[o.options[i].Value]
+}
+
+// arrange orders the options with the given values first, in the same order,
+// followed by the other options. Pinned options keep their position.
+//
+// Options are placed one by one, so that options sharing a value are placed
+// once for each time the value is given, keeping their current order.
+func (o *OrderedList[T]) arrange(values []T) {
+	options := o.options
+	if len(options) != len(o.original) {
+		options = o.original
+	}
+	var movable []Option[T]
+	placed := make([]bool, len(options))
+	for _, v := range values {
+		for i, option := range options {
+			if !placed[i] && option.Value == v && !o.pinned[v] {
+				movable = append(movable, option)
+				placed[i] = true
+				break
+			}
+		}
+	}
+	for i, option := range options {
+		if !placed[i] && !o.pinned[option.Value] {
+			movable = append(movable, option)
+		}
+	}
+	o.place(movable)
+}
+
+// place sets the options to the given options that are not pinned, in order,
+// with the pinned options at their original position.
+func (o *OrderedList[T]) place(movable []Option[T]) {
+	o.options = make([]Option[T], len(o.original))
+	for i, option := range o.original {
+		if o.pinned[option.Value] {
+			o.options[i] = option
+			continue
+		}
+		o.options[i], movable = movable[0], movable[1:]
+	}
+	o.cursor = clamp(o.cursor, 0, len(o.options)-1)
+	o.updateValue()
+}
+
+// ranked returns the values of the ranked options, in order.
+func (o *OrderedList[T]) ranked() []T {
+	n := len(o.options)
+	if o.limit > 0 {
+		n = min(n, o.limit)
+	}
+	values := make([]T, n)
+	for i := range values {
+		values[i] = o.options[i].Value
+	}
+	return values
+}
+
+// order returns the values of all options, in order.
+func (o *OrderedList[T]) order() []T {
+	values := make([]T, len(o.options))
+	for i, option := range o.options {
+		values[i] = option.Value
+	}
+	return values
+}
+
+// updateValue sets the value to the ranked options.
+func (o *OrderedList[T]) updateValue() {
+	if len(o.options) > 0 {
+		o.accessor.Set(o.ranked())
+	}
+}
+
+// isRanked returns whether the option at index i is ranked.
+func (o *OrderedList[T]) isRanked(i int) bool {
+	return o.limit <= 0 || i < o.limit
+}
+
+// line returns the line of the option at index i, which is one more for the
+// options listed below the separator.
+func (o *OrderedList[T]) line(i int) int {
+	if !o.isRanked(i) {
+		return i + 1
+	}
+	return i
+}
+
+// keepInView scrolls the viewport so that the cursor is in view.
+func (o *OrderedList[T]) keepInView() {
+	o.viewport.SetContent(o.optionsView())
+	line := o.line(o.cursor)
+	if line < o.viewport.YOffset {
+		o.viewport.SetYOffset(line)
+	} else if line >= o.viewport.YOffset+o.viewport.Height {
+		o.viewport.SetYOffset(line - o.viewport.Height + 1)
+	}
+}
+
+// updateViewportHeight updates the viewport size according to the Height
+// setting on this ordered list field.
+func (o *OrderedList[T]) updateViewportHeight() {
+	// If no height is set size the viewport to the number of lines.
+	if o.height <= 0 {
+		o.viewport.Height = max(minHeight, o.line(len(o.options)-1)+1)
+		return
+	}
+
+	o.viewport.Height = max(minHeight, o.height-
+		lipgloss.Height(o.titleView())-
+		lipgloss.Height(o.descriptionView()))
+}
+
+func (o *OrderedList[T]) activeStyles() *FieldStyles {
+	theme := o.theme
+	if theme == nil {
+		theme = ThemeCharm()
+	}
+	if o.focused {
+		return &theme.Focused
+	}
+	return &theme.Blurred
+}
+
+func (o *OrderedList[T]) titleView() string {
+	styles := o.activeStyles()
+	title := styles.Title.Render(o.title)
+	if o.err != nil {
+		title += styles.ErrorIndicator.String()
+	}
+	return title
+}
+
+func (o *OrderedList[T]) descriptionView() string {
+	return markdownDescription(o.theme, o.activeStyles(), o.description, o.width)
+}
+
+// optionsView renders the options with their rank. Options that are not
+// ranked are listed below a separator.
+func (o *OrderedList[T]) optionsView() string {
+	var (
+		styles = o.activeStyles()
+		c      = styles.SelectSelector.String()
+		pad    = len(strconv.Itoa(len(o.options))) + len(". ")
+		sb     strings.Builder
+	)
+
+	for i, option := range o.options {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		if i == o.limit && o.limit > 0 {
+			sb.WriteString(strings.Repeat(" ", lipgloss.Width(c)) + styles.OptionSeparator.String() + "\n")
+		}
+
+		if o.cursor == i {
+			sb.WriteString(c)
+		} else {
+			sb.WriteString(strings.Repeat(" ", lipgloss.Width(c)))
+		}
+		rank := ""
+		if o.isRanked(i) {
+			rank = strconv.Itoa(i+1) + "."
+		}
+		sb.WriteString(styles.OptionDescription.Render(fmt.Sprintf("%-*s", pad, rank)))
+
+		style := styles.UnselectedOption
+		if o.cursor == i {
+			style = styles.SelectedOption
+		}
+		sb.WriteString(style.Render(option.Key))
+		if o.isPinned(i) {
+			sb.WriteString(" " + styles.OptionDescription.Render("(pinned)"))
+		}
+	}
+
+	return sb.String()
+}
+
+// View renders the ordered list field.
+func (o *OrderedList[T]) View() string {
+	styles := o.activeStyles()
+	o.updateViewportHeight()
+	o.viewport.SetContent(o.optionsView())
+
+	var sb strings.Builder
+	if o.title != "" {
+		sb.WriteString(o.titleView())
+		sb.WriteString("\n")
+	}
+	if o.description != "" {
+		sb.WriteString(o.descriptionView())
+		sb.WriteString("\n")
+	}
+	sb.WriteString(o.viewport.View())
+	return styles.Base.Render(sb.String())
+}
+
+// Run runs the ordered list field.
+func (o *OrderedList[T]) Run() error {
+	if o.accessible {
+		return o.RunAccessible(os.Stdout, os.Stdin)
+	}
+	return Run(o)
+}
+
+// RunAccessible runs an accessible ordered list field, reading answers from
+// r and writing prompts to w.
+//
+// The new position of each option is asked in turn, the current position
+// being kept when the answer is left empty. Pinned options are not asked for.
+func (o *OrderedList[T]) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	rules := []string{"Enter the new position of each option."}
+	if o.limit > 0 && o.limit < len(o.options) {
+		rules = append(rules, fmt.Sprintf("Only the first %d options are ranked.", o.limit))
+	}
+	a.announce(o.title, plainDescription(o.theme, o.description), rules...)
+	if len(o.options) == 0 {
+		fmt.Fprintln(w, "There are no options.")
+		fmt.Fprintln(w)
+		return nil
+	}
+
+	for {
+		o.printOptions(w)
+		for _, option := range append([]Option[T](nil), o.options...) {
+			if o.pinned[option.Value] {
+				continue
+			}
+			current := o.indexOf(option)
+			input, err := a.prompt("Position of "+plain(option.Key), strconv.Itoa(current+1), func(input string) error {
+				if err := validChoice(1, len(o.options))(input); err != nil {
+					return err
+				}
+				if i, _ := strconv.Atoi(input); i-1 != current && o.isPinned(i-1) {
+					return fmt.Errorf("position %d is pinned", i)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			i, _ := strconv.Atoi(input)
+			o.moveTo(current, i-1)
+		}
+
+		if err := o.validate(o.accessor.Get()); err != nil {
+			a.reject(err)
+			continue
+		}
+		break
+	}
+
+	o.printOptions(w)
+	_, answer := o.summarize()
+	a.answer(answer)
+	return nil
+}
+
+// printOptions prints the options in their current order for accessible
+// mode.
+func (o *OrderedList[T]) printOptions(w io.Writer) {
+	for i, option := range o.options {
+		line := fmt.Sprintf("%d. %s", i+1, plain(option.Key))
+		switch {
+		case o.isPinned(i):
+			line += " (pinned)"
+		case !o.isRanked(i):
+			line += " (not ranked)"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// indexOf returns the index of the given option, or -1.
+func (o *OrderedList[T]) indexOf(option Option[T]) int {
+	for i, other := range o.options {
+		if other.Key == option.Key && other.Value == option.Value {
+			return i
+		}
+	}
+	return -1
+}
+
+// moveTo moves the option at index i to index j, shifting the options that
+// are not pinned in between.
+func (o *OrderedList[T]) moveTo(i, j int) {
+	var movable []Option[T]
+	for k, option := range o.options {
+		if k != i && !o.isPinned(k) {
+			movable = append(movable, option)
+		}
+	}
+	// Count the options that are not pinned before the new position.
+	var n int
+	for k := 0; k < j; k++ {
+		if !o.isPinned(k) {
+			n++
+		}
+	}
+	movable = append(movable[:n], append([]Option[T]{o.options[i]}, movable[n:]...)...)
+	o.place(movable)
+}
+
+// WithTheme sets the theme of the ordered list field.
+func (o *OrderedList[T]) WithTheme(theme *Theme) Field {
+	if o.theme != nil {
+		return o
+	}
+	o.theme = theme
+	o.updateViewportHeight()
+	return o
+}
+
+// WithKeyMap sets the keymap on an ordered list field.
+func (o *OrderedList[T]) WithKeyMap(k *KeyMap) Field {
+	o.keymap = k.OrderedList
+	return o
+}
+
+// WithAccessible sets the accessible mode of the ordered list field.
+func (o *OrderedList[T]) WithAccessible(accessible bool) Field {
+	o.accessible = accessible
+	return o
+}
+
+// WithWidth sets the width of the ordered list field.
+func (o *OrderedList[T]) WithWidth(width int) Field {
+	o.width = width
+	return o
+}
+
+// WithHeight sets the height of the ordered list field.
+func (o *OrderedList[T]) WithHeight(height int) Field {
+	return o.Height(height)
+}
+
+// WithPosition sets the position of the ordered list field.
+func (o *OrderedList[T]) WithPosition(p FieldPosition) Field {
+	o.keymap.Prev.SetEnabled(!p.IsFirst())
+	o.keymap.Next.SetEnabled(!p.IsLast())
+	o.keymap.Submit.SetEnabled(p.IsLast())
+	return o
+}
+
+// GetKey returns the key of the field.
+func (o *OrderedList[T]) GetKey() string { return o.key }
+
+// GetValue returns the value of the field.
+func (o *OrderedList[T]) GetValue() any {
+	return o.accessor.Get()
+}
+
+// summarize returns the title and the keys of the ranked options, in order,
+// for the review page.
+func (o *OrderedList[T]) summarize() (string, string) {
+	n := len(o.ranked())
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = o.options[i].Key
+	}
+	return o.title, strings.Join(keys, ", ")
+}
+
+// HoveredKey returns the key of the option under the cursor, or an empty
+// string if there is none.
+func (o *OrderedList[T]) HoveredKey() string {
+	if o.cursor < 0 || o.cursor >= len(o.options) {
+		return ""
+	}
+	return o.options[o.cursor].Key
+}
+
+// setValue sets the value of the field from an answer, a list of options by
+// value or by key in the order they are ranked. The options left out follow
+// in their current order.
+func (o *OrderedList[T]) setValue(value any) error {
+	if v, ok := value.([]T); ok {
+		value = toAnySlice(v)
+	}
+	var values []T
+	seen := make(map[T]bool)
+	for _, answer := range answerList(value) {
+		i := findOption(o.original, answer)
+		if i < 0 {
+			return fmt.Errorf("%v is not one of the options", answer)
+		}
+		v := o.original[i].Value
+		if seen[v] {
+			return fmt.Errorf("%v is ranked more than once", answer)
+		}
+		seen[v] = true
+		values = append(values, v)
+	}
+
+	previous := o.options
+	o.arrange(append(values, o.order()...))
+	if err := o.validate(o.ranked()); err != nil {
+		o.options = previous
+		o.updateValue()
+		return err
+	}
+	return nil
+}
@@ -1,7 +1,11 @@
 package huh
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,7 +14,6 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/thedeveloper-sharath/huh/accessibility"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -26,6 +29,8 @@ const (
 // or OptionsFunc. The options can be filtered using "/" and navigation is done
 // using j/k, up/down, or ctrl+n/ctrl+p keys.
 type Select[T comparable] struct {
+	fieldHide
+
 	id       int
 	accessor Accessor[T]
 	key      string
@@ -35,15 +40,19 @@ type Select[T comparable] struct {
 	title           Eval[string]
 	description     Eval[string]
 	options         Eval[[]Option[T]]
+	source          *optionLoader[T]
 	filteredOptions []Option[T]
+	preview         *optionPreview[T]
 
-	validate func(T) error
-	err      error
+	validate      func(T) error
+	validateAsync *asyncValidation[T]
+	err           error
 
 	selected  int
 	focused   bool
 	filtering bool
 	filter    textinput.Model
+	filterFn  FilterFunc
 	spinner   spinner.Model
 
 	inline     bool
@@ -66,14 +75,18 @@ func NewSelect[T comparable]() *Select[T] {
 
 	s := spinner.New(spinner.WithSpinner(spinner.Line))
 
+	id := nextID()
 	return &Select[T]{
+		id:          id,
 		accessor:    &EmbeddedAccessor[T]{},
 		validate:    func(T) error { return nil },
 		filtering:   false,
 		filter:      filter,
+		filterFn:    FilterContains,
 		options:     Eval[[]Option[T]]{cache: make(map[uint64][]Option[T])},
 		title:       Eval[string]{cache: make(map[uint64]string)},
 		description: Eval[string]{cache: make(map[uint64]string)},
+		preview:     newOptionPreview[T](id),
 		spinner:     s,
 	}
 }
@@ -92,8 +105,13 @@ func (s *Select[T]) Accessor(accessor Accessor[T]) *Select[T] {
 }
 
 func (s *Select[T]) selectValue(value T) {
+	if s.source != nil {
+		s.selected = s.source.indexOf(value)
+		s.source.locate = s.selected < 0
+		return
+	}
 	for i, o := range s.options.val {
-		if o.Value == value {
+		if o.isChoice() && o.Value == value {
 			s.selected = i
 			break
 		}
@@ -136,6 +154,19 @@ func (s *Select[T]) Filtering(filtering bool) *Select[T] {
 	return s
 }
 
+// Filter sets the func used to match and rank options against the filter.
+//
+// By default options are matched with FilterContains. Use FilterFuzzy,
+// FilterPrefix, FilterIgnoreDiacritics or a FilterFunc of your own for other
+// strategies. A nil func restores the default.
+func (s *Select[T]) Filter(fn FilterFunc) *Select[T] {
+	if fn == nil {
+		fn = FilterContains
+	}
+	s.filterFn = fn
+	return s
+}
+
 // Description sets the description of the select field.
 //
 // This description will be static, for dynamic descriptions use `DescriptionFunc`.
@@ -178,6 +209,9 @@ func (s *Select[T]) Options(options ...Option[T]) *Select[T] {
 
 	// Set the cursor to the existing value or the last selected option.
 	for i, option := range options {
+		if !option.isChoice() {
+			continue
+		}
 		if option.Value == s.accessor.Get() {
 			s.selected = i
 			break
@@ -185,6 +219,7 @@ func (s *Select[T]) Options(options ...Option[T]) *Select[T] {
 			s.selected = i
 		}
 	}
+	s.selected = nearestOption(optionSlice[T](options), s.selected, 1)
 
 	s.updateViewportHeight()
 	s.updateValue()
@@ -227,6 +262,23 @@ func (s *Select[T]) OptionsFunc(f func() []Option[T], bindings any) *Select[T] {
 	return s
 }
 
+// OptionsSource sets a source to load the options of the select field from on
+// demand.
+//
+// Use this instead of Options or OptionsFunc for lists that are too large to
+// load at once. Pages of options are fetched as the user scrolls, and the
+// filter is passed on to the source rather than applied by the field. Until
+// the option holding the field's value is loaded, the value is kept as is.
+func (s *Select[T]) OptionsSource(source OptionSource[T]) *Select[T] {
+	s.source = newOptionLoader(s.id, source)
+	s.selectValue(s.accessor.Get())
+	if s.height <= 0 {
+		s.height = defaultHeight
+		s.updateViewportHeight()
+	}
+	return s
+}
+
 // Inline sets whether the select input should be inline.
 func (s *Select[T]) Inline(v bool) *Select[T] {
 	s.inline = v
@@ -248,17 +300,83 @@ func (s *Select[T]) Height(height int) *Select[T] {
 	return s
 }
 
+// Preview sets the function rendering a preview of the option under the
+// cursor, such as the contents of a file or the details of a record.
+//
+// Previews are computed in a command when the cursor moves to an option, and
+// cached by the value of the option. They are shown in a pane to the right of
+// the options unless PreviewPosition says otherwise, and scrolled with
+// shift+up and shift+down.
+func (s *Select[T]) Preview(preview func(T) string) *Select[T] {
+	s.preview.fn = preview
+	s.keymap.PreviewUp.SetEnabled(preview != nil)
+	s.keymap.PreviewDown.SetEnabled(preview != nil)
+	s.updateViewportHeight()
+	return s
+}
+
+// PreviewPosition sets whether the preview is shown to the right of the
+// options or below them. Fields too narrow for a pane show the preview
+// inline, below the options.
+func (s *Select[T]) PreviewPosition(position PreviewPosition) *Select[T] {
+	s.preview.position = position
+	s.updateViewportHeight()
+	return s
+}
+
+// PreviewSize sets the width of a preview pane to the right of the options,
+// or the height of a preview below them. By default a pane to the right takes
+// half of the field and a preview below takes 6 lines.
+func (s *Select[T]) PreviewSize(size int) *Select[T] {
+	s.preview.size = size
+	s.updateViewportHeight()
+	return s
+}
+
 // Validate sets the validation function of the select field.
 func (s *Select[T]) Validate(validate func(T) error) *Select[T] {
 	s.validate = validate
 	return s
 }
 
+// ValidateAsync sets the asynchronous validation function of the select field.
+//
+// The function runs as a command once the selection settles, after the
+// synchronous validation passes, and its context is cancelled when the
+// selection changes again. The field can't be submitted until the validation
+// resolves.
+func (s *Select[T]) ValidateAsync(validate func(context.Context, T) error) *Select[T] {
+	s.validateAsync = newAsyncValidation(s.id, validate)
+	return s
+}
+
 // Error returns the error of the select field.
-func (s *Select[T]) Error() error { return s.err }
+func (s *Select[T]) Error() error {
+	if s.err != nil {
+		return s.err
+	}
+	if s.source != nil && s.source.err != nil {
+		return s.source.err
+	}
+	return s.validateAsync.error()
+}
 
 // Skip returns whether the select should be skipped or should be blocking.
-func (*Select[T]) Skip() bool { return false }
+// Hidden selects are skipped.
+func (s *Select[T]) Skip() bool { return s.hidden() }
+
+// WithHideFunc sets the function that checks if the select should be hidden.
+func (s *Select[T]) WithHideFunc(hideFunc func() bool) *Select[T] {
+	s.setHideFunc(hideFunc)
+	return s
+}
+
+// WithShowWhen sets the function that checks if the select should be shown.
+// It is the opposite of WithHideFunc.
+func (s *Select[T]) WithShowWhen(showFunc func() bool) *Select[T] {
+	s.setShowWhen(showFunc)
+	return s
+}
 
 // Zoom returns whether the input should be zoomed.
 func (*Select[T]) Zoom() bool { return false }
@@ -266,7 +384,7 @@ func (*Select[T]) Zoom() bool { return false }
 // Focus focuses the select field.
 func (s *Select[T]) Focus() tea.Cmd {
 	s.focused = true
-	return nil
+	return s.validateAsync.init(s.accessor.Get())
 }
 
 // Blur blurs the select field.
@@ -291,6 +409,8 @@ func (s *Select[T]) KeyBinds() []key.Binding {
 		s.keymap.Filter,
 		s.keymap.SetFilter,
 		s.keymap.ClearFilter,
+		s.keymap.PreviewUp,
+		s.keymap.PreviewDown,
 		s.keymap.Prev,
 		s.keymap.Next,
 		s.keymap.Submit,
@@ -306,6 +426,10 @@ func (s *Select[T]) Init() tea.Cmd {
 func (s *Select[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	s.updateViewportHeight()
 
+	if cmd := s.validateAsync.update(msg); cmd != nil {
+		return s, cmd
+	}
+
 	var cmd tea.Cmd
 	if s.filtering {
 		s.filter, cmd = s.filter.Update(msg)
@@ -342,7 +466,7 @@ func (s *Select[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			s.options.bindingsHash = hash
 			if s.options.loadFromCache() {
 				s.filteredOptions = s.options.val
-				s.selected = clamp(s.selected, 0, len(s.options.val)-1)
+				s.selected = nearestOption(optionSlice[T](s.options.val), clamp(s.selected, 0, len(s.options.val)-1), 1)
 			} else {
 				s.options.loading = true
 				s.options.loadingStart = time.Now()
@@ -351,6 +475,10 @@ func (s *Select[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}, s.spinner.Tick)
 			}
 		}
+		if s.source != nil {
+			cmds = append(cmds, s.fetchOptions())
+		}
+		cmds = append(cmds, s.updatePreview())
 		return s, tea.Batch(cmds...)
 
 	case spinner.TickMsg:
@@ -374,18 +502,34 @@ func (s *Select[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			// since we're updating the options, we need to update the selected cursor
 			// position and filteredOptions.
-			s.selected = clamp(s.selected, 0, len(msg.options)-1)
+			s.selected = nearestOption(optionSlice[T](msg.options), clamp(s.selected, 0, len(msg.options)-1), 1)
 			s.filteredOptions = msg.options
 			s.updateValue()
 		}
+	case optionPageMsg[T]:
+		if s.source != nil && s.source.update(msg) {
+			s.updateOptionPage(msg.offset)
+		}
+	case optionFilterMsg:
+		if s.source != nil && s.source.settled(msg) {
+			s.resetSource(s.source.pending)
+			return s, s.fetchOptions()
+		}
+	case updatePreviewMsg:
+		s.preview.update(msg)
 	case tea.KeyMsg:
 		s.err = nil
+		before := s.accessor.Get()
 		switch {
+		case key.Matches(msg, s.keymap.PreviewUp):
+			s.preview.scroll(-1)
+		case key.Matches(msg, s.keymap.PreviewDown):
+			s.preview.scroll(1)
 		case key.Matches(msg, s.keymap.Filter):
 			s.setFiltering(true)
 			return s, s.filter.Focus()
 		case key.Matches(msg, s.keymap.SetFilter):
-			if len(s.filteredOptions) <= 0 {
+			if s.rows().Len() <= 0 && !s.options.loading {
 				s.filter.SetValue("")
 				s.filteredOptions = s.options.val
 			}
@@ -402,34 +546,35 @@ func (s *Select[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if s.filtering && (msg.String() == "k" || msg.String() == "h") {
 				break
 			}
-			s.selected = s.selected - 1
-			if s.selected < 0 {
-				s.selected = len(s.filteredOptions) - 1
+			prev := s.selected
+			s.selected = nextOption(s.rows(), max(s.selected, 0), -1, true)
+			if s.selected > prev {
 				s.viewport.GotoBottom()
 			}
-			if s.selected < s.viewport.YOffset {
-				s.viewport.SetYOffset(s.selected)
+			// Show the header of the option's group along with it.
+			if top := firstRowOf(s.rows(), s.selected); top < s.viewport.YOffset {
+				s.viewport.SetYOffset(top)
 			}
 			s.updateValue()
 		case key.Matches(msg, s.keymap.GotoTop):
 			if s.filtering {
 				break
 			}
-			s.selected = 0
+			s.selected = nearestOption(s.rows(), 0, 1)
 			s.viewport.GotoTop()
 			s.updateValue()
 		case key.Matches(msg, s.keymap.GotoBottom):
 			if s.filtering {
 				break
 			}
-			s.selected = len(s.filteredOptions) - 1
+			s.selected = nearestOption(s.rows(), s.rows().Len()-1, -1)
 			s.viewport.GotoBottom()
 		case key.Matches(msg, s.keymap.HalfPageUp):
-			s.selected = max(s.selected-s.viewport.Height/2, 0)
+			s.selected = nearestOption(s.rows(), max(s.selected-s.viewport.Height/2, 0), -1)
 			s.viewport.HalfViewUp()
 			s.updateValue()
 		case key.Matches(msg, s.keymap.HalfPageDown):
-			s.selected = min(s.selected+s.viewport.Height/2, len(s.filteredOptions)-1)
+			s.selected = nearestOption(s.rows(), min(s.selected+s.viewport.Height/2, s.rows().Len()-1), 1)
 			s.viewport.HalfViewDown()
 			s.updateValue()
 		case key.Matches(msg, s.keymap.Down, s.keymap.Right):
@@ -439,64 +584,175 @@ func (s *Select[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if s.filtering && (msg.String() == "j" || msg.String() == "l") {
 				break
 			}
-			s.selected = s.selected + 1
-			if s.selected > len(s.filteredOptions)-1 {
-				s.selected = 0
+			prev := s.selected
+			s.selected = nextOption(s.rows(), s.selected, 1, true)
+			if s.selected < prev {
 				s.viewport.GotoTop()
 			}
 			if s.selected >= s.viewport.YOffset+s.viewport.Height {
-				s.viewport.LineDown(1)
+				s.viewport.SetYOffset(s.selected - s.viewport.Height + 1)
 			}
 			s.updateValue()
 		case key.Matches(msg, s.keymap.Prev):
-			if s.selected >= len(s.filteredOptions) {
+			if !s.canChoose() {
 				break
 			}
 			s.updateValue()
 			s.err = s.validate(s.accessor.Get())
-			if s.err != nil {
+			if s.err != nil || s.validateAsync.blocked() {
 				return s, nil
 			}
 			s.updateValue()
 			return s, PrevField
 		case key.Matches(msg, s.keymap.Next, s.keymap.Submit):
-			if s.selected >= len(s.filteredOptions) {
+			if !s.canChoose() {
 				break
 			}
 			s.setFiltering(false)
 			s.updateValue()
 			s.err = s.validate(s.accessor.Get())
-			if s.err != nil {
+			if s.err != nil || s.validateAsync.blocked() {
 				return s, nil
 			}
 			s.updateValue()
 			return s, NextField
 		}
 
-		if s.filtering {
-			s.filteredOptions = s.options.val
-			if s.filter.Value() != "" {
-				s.filteredOptions = nil
-				for _, option := range s.options.val {
-					if s.filterFunc(option.Key) {
-						s.filteredOptions = append(s.filteredOptions, option)
-					}
-				}
-			}
+		if s.filtering && s.source == nil {
+			s.filteredOptions = filterOptions(s.options.val, s.filter.Value(), s.filterFn)
 			if len(s.filteredOptions) > 0 {
-				s.selected = min(s.selected, len(s.filteredOptions)-1)
+				s.selected = nearestOption(optionSlice[T](s.filteredOptions), min(s.selected, len(s.filteredOptions)-1), 1)
 				s.viewport.SetYOffset(clamp(s.selected, 0, len(s.filteredOptions)-s.viewport.Height))
 			}
 		}
+
+		if s.source != nil {
+			// Show the header of the option's group along with it.
+			s.source.keepInView(firstRowOf(s.rows(), s.selected), s.viewport.Height)
+			s.source.keepInView(s.selected, s.viewport.Height)
+		}
+
+		if value := s.accessor.Get(); value != before {
+			if s.validate(value) == nil {
+				return s, tea.Batch(cmd, s.validateAsync.schedule(value))
+			}
+			s.validateAsync.reset()
+		}
 	}
 
 	return s, cmd
 }
 
+// canChoose returns whether the option under the cursor can be chosen. It
+// may be the value that is not loaded from the options source yet.
+func (s *Select[T]) canChoose() bool {
+	rows := s.rows()
+	if s.selected >= rows.Len() {
+		return false
+	}
+	return s.selected < 0 || (s.isLoaded(s.selected) && rows.At(s.selected).selectable())
+}
+
+// rows returns the options shown, either the filtered options or those of
+// the options source.
+func (s *Select[T]) rows() optionList[T] {
+	if s.source != nil {
+		return s.source
+	}
+	return optionSlice[T](s.filteredOptions)
+}
+
 func (s *Select[T]) updateValue() {
-	if s.selected < len(s.filteredOptions) && s.selected >= 0 {
-		s.accessor.Set(s.filteredOptions[s.selected].Value)
+	rows := s.rows()
+	if s.selected < 0 || s.selected >= rows.Len() || !rows.At(s.selected).selectable() {
+		return
+	}
+	if s.source != nil {
+		// The option under the cursor becomes the value once it is loaded.
+		s.source.locate = false
+		if !s.source.isLoaded(s.selected) {
+			return
+		}
+	}
+	s.accessor.Set(rows.At(s.selected).Value)
+}
+
+// updatePreview computes the preview of the option under the cursor, or
+// clears it when there is no option to preview.
+func (s *Select[T]) updatePreview() tea.Cmd {
+	rows := s.rows()
+	if s.selected < 0 || s.selected >= rows.Len() ||
+		!s.isLoaded(s.selected) || !rows.At(s.selected).isChoice() {
+		s.preview.clear()
+		return nil
+	}
+	return s.preview.hover(rows.At(s.selected).Value)
+}
+
+// isLoaded returns whether the option at index i is loaded, which is always
+// the case without an options source.
+func (s *Select[T]) isLoaded(i int) bool {
+	return s.source == nil || s.source.isLoaded(i)
+}
+
+// fetchOptions fetches the options in view and under the cursor that are not
+// loaded yet. A change of filter restarts the options source once the filter
+// has settled, or right away when it is cleared.
+func (s *Select[T]) fetchOptions() tea.Cmd {
+	var cmds []tea.Cmd
+	if filter := s.filter.Value(); filter == "" && s.source.filter != "" {
+		s.resetSource(filter)
+	} else {
+		cmds = append(cmds, s.source.setFilter(filter))
 	}
+	if s.source.total < 0 && s.source.err == nil && !s.options.loading {
+		s.options.loading = true
+		s.options.loadingStart = time.Now()
+		cmds = append(cmds, s.spinner.Tick)
+	}
+
+	cmds = append(cmds,
+		s.source.fetch(s.source.offset, s.source.offset+s.viewport.Height),
+		s.source.fetch(s.selected, s.selected+1))
+	return tea.Batch(cmds...)
+}
+
+// resetSource restarts the options source with the given filter, moving the
+// cursor to the value when the filter is cleared and to the first option
+// otherwise.
+func (s *Select[T]) resetSource(filter string) {
+	s.source.reset(filter)
+	if filter == "" {
+		s.selectValue(s.accessor.Get())
+	} else {
+		s.selected = 0
+		s.source.locate = false
+	}
+}
+
+// updateOptionPage updates the field after a page of options was loaded from
+// the options source at the given offset.
+func (s *Select[T]) updateOptionPage(offset int) {
+	s.options.loading = false
+	s.selected = min(s.selected, s.source.Len()-1)
+	if s.source.locate {
+		var zero T
+		value := s.accessor.Get()
+		if i := s.source.indexOf(value); i >= 0 {
+			s.selected = i
+			s.source.locate = false
+		} else if offset == 0 && value == zero {
+			s.selected = 0
+			s.updateValue()
+		} else if offset == 0 {
+			s.selected = -1
+		}
+	} else {
+		s.updateValue()
+	}
+
+	// Keep the cursor in view now that the options are known.
+	s.source.keepInView(s.selected, s.viewport.Height)
 }
 
 // updateViewportHeight updates the viewport size according to the Height setting
@@ -510,7 +766,13 @@ func (s *Select[T]) updateViewportHeight() {
 
 	s.viewport.Height = max(minHeight, s.height-
 		lipgloss.Height(s.titleView())-
-		lipgloss.Height(s.descriptionView()))
+		lipgloss.Height(s.descriptionView())-
+		s.preview.height(s.previewWidth(), s.activeStyles()))
+}
+
+// previewWidth returns the width of the options and their preview.
+func (s *Select[T]) previewWidth() int {
+	return s.width - s.activeStyles().Base.GetHorizontalFrameSize()
 }
 
 func (s *Select[T]) activeStyles() *FieldStyles {
@@ -536,14 +798,15 @@ func (s *Select[T]) titleView() string {
 	} else {
 		sb.WriteString(styles.Title.Render(s.title.val))
 	}
-	if s.err != nil {
+	sb.WriteString(s.validateAsync.view(styles))
+	if s.Error() != nil {
 		sb.WriteString(styles.ErrorIndicator.String())
 	}
 	return sb.String()
 }
 
 func (s *Select[T]) descriptionView() string {
-	return s.activeStyles().Description.Render(s.description.val)
+	return markdownDescription(s.theme, s.activeStyles(), s.description.val, s.width)
 }
 
 func (s *Select[T]) optionsView() string {
@@ -561,20 +824,30 @@ func (s *Select[T]) optionsView() string {
 
 	if s.inline {
 		sb.WriteString(styles.PrevIndicator.Faint(s.selected <= 0).String())
-		if len(s.filteredOptions) > 0 {
-			sb.WriteString(styles.SelectedOption.Render(s.filteredOptions[s.selected].Key))
-		} else {
+		switch {
+		case s.rows().Len() == 0:
 			sb.WriteString(styles.TextInput.Placeholder.Render("No matches"))
+		case s.selected < 0:
+			// The value has not been loaded from the options source yet.
+			sb.WriteString(styles.SelectedOption.Render(fmt.Sprint(s.accessor.Get())))
+		case !s.isLoaded(s.selected):
+			sb.WriteString(styles.TextInput.Placeholder.Render("Loading..."))
+		default:
+			sb.WriteString(s.optionView(s.rows().At(s.selected), styles.SelectedOption))
 		}
-		sb.WriteString(styles.NextIndicator.Faint(s.selected == len(s.filteredOptions)-1).String())
+		sb.WriteString(styles.NextIndicator.Faint(s.selected == s.rows().Len()-1).String())
 		return sb.String()
 	}
 
+	if s.source != nil {
+		return s.sourceOptionsView()
+	}
+
 	for i, option := range s.filteredOptions {
 		if s.selected == i {
-			sb.WriteString(c + styles.SelectedOption.Render(option.Key))
+			sb.WriteString(c + s.optionView(option, styles.SelectedOption))
 		} else {
-			sb.WriteString(strings.Repeat(" ", lipgloss.Width(c)) + styles.UnselectedOption.Render(option.Key))
+			sb.WriteString(strings.Repeat(" ", lipgloss.Width(c)) + s.optionView(option, styles.UnselectedOption))
 		}
 		if i < len(s.options.val)-1 {
 			sb.WriteString("\n")
@@ -588,10 +861,50 @@ func (s *Select[T]) optionsView() string {
 	return sb.String()
 }
 
+// sourceOptionsView renders the options of an options source in view. Only
+// those are rendered, since there may be a great many options, and the field
+// scrolls them itself.
+func (s *Select[T]) sourceOptionsView() string {
+	var (
+		styles = s.activeStyles()
+		c      = styles.SelectSelector.String()
+		sb     strings.Builder
+		from   = s.source.offset
+		to     = min(from+s.viewport.Height, s.source.Len())
+	)
+
+	for i := from; i < to; i++ {
+		if i > from {
+			sb.WriteString("\n")
+		}
+		prefix, style := strings.Repeat(" ", lipgloss.Width(c)), styles.UnselectedOption
+		if s.selected == i {
+			prefix, style = c, styles.SelectedOption
+		}
+		if s.isLoaded(i) {
+			sb.WriteString(prefix + s.optionView(s.source.At(i), style))
+		} else {
+			sb.WriteString(prefix + styles.TextInput.Placeholder.Render("Loading..."))
+		}
+	}
+
+	return sb.String()
+}
+
+// optionView renders an option, highlighting the characters of its key that
+// match the filter.
+func (s *Select[T]) optionView(option Option[T], style lipgloss.Style) string {
+	return renderOption(option, s.filter.Value(), s.filterFn, style, s.activeStyles())
+}
+
 // View renders the select field.
 func (s *Select[T]) View() string {
 	styles := s.activeStyles()
 	s.viewport.SetContent(s.optionsView())
+	if s.source != nil {
+		// The options in view are the whole content.
+		s.viewport.SetYOffset(0)
+	}
 
 	var sb strings.Builder
 	if s.title.val != "" || s.title.fn != nil {
@@ -606,7 +919,7 @@ func (s *Select[T]) View() string {
 			sb.WriteString("\n")
 		}
 	}
-	sb.WriteString(s.viewport.View())
+	sb.WriteString(s.preview.view(s.viewport.View(), s.previewWidth(), styles))
 	return styles.Base.Render(sb.String())
 }
 
@@ -628,46 +941,83 @@ func (s *Select[T]) setFiltering(filtering bool) {
 	s.keymap.ClearFilter.SetEnabled(!filtering && s.filter.Value() != "")
 }
 
-// filterFunc returns true if the option matches the filter.
-func (s *Select[T]) filterFunc(option string) bool {
-	// XXX: remove diacritics or allow customization of filter function.
-	return strings.Contains(strings.ToLower(option), strings.ToLower(s.filter.Value()))
-}
-
 // Run runs the select field.
 func (s *Select[T]) Run() error {
 	if s.accessible {
-		return s.runAccessible()
+		return s.RunAccessible(os.Stdout, os.Stdin)
 	}
 	return Run(s)
 }
 
-// runAccessible runs an accessible select field.
-func (s *Select[T]) runAccessible() error {
-	var sb strings.Builder
-	styles := s.activeStyles()
-	sb.WriteString(styles.Title.Render(s.title.val) + "\n")
+// RunAccessible runs an accessible select field, reading answers from r and
+// writing prompts to w.
+func (s *Select[T]) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	a.announce(s.title.val, plainDescription(s.theme, s.description.val), "Enter the number of an option.")
+
+	options := s.options.val
+	if s.source != nil {
+		var err error
+		if options, err = s.searchOptions(a); err != nil {
+			return err
+		}
+	}
+	if len(options) == 0 {
+		fmt.Fprintln(w, "There are no options.")
+		fmt.Fprintln(w)
+		return nil
+	}
 
-	for i, option := range s.options.val {
-		sb.WriteString(fmt.Sprintf("%d. %s", i+1, option.Key))
-		sb.WriteString("\n")
+	var current string
+	choices := printChoices(w, options, func(Option[T]) string { return "" })
+	for n, i := range choices {
+		if current == "" && options[i].selectable() && options[i].Value == s.accessor.Get() {
+			current = strconv.Itoa(n + 1)
+		}
 	}
 
-	fmt.Println(sb.String())
+	validate := withAsyncValidation(s.validate, s.validateAsync)
+	input, err := a.prompt("Choose", current, func(input string) error {
+		if err := validChoice(1, len(choices))(input); err != nil {
+			return err
+		}
+		n, _ := strconv.Atoi(input)
+		if option := options[choices[n-1]]; option.disabled {
+			return errOptionDisabled(option)
+		}
+		return validate(options[choices[n-1]].Value)
+	})
+	if err != nil {
+		return err
+	}
+	n, _ := strconv.Atoi(input)
+	option := options[choices[n-1]]
+	s.accessor.Set(option.Value)
+	a.answer(option.Key)
+	return nil
+}
 
+// searchOptions prompts for a filter and fetches the first page of matching
+// options from the options source.
+func (s *Select[T]) searchOptions(a *accessibleRenderer) ([]Option[T], error) {
 	for {
-		choice := accessibility.PromptInt("Choose: ", 1, len(s.options.val))
-		option := s.options.val[choice-1]
-		if err := s.validate(option.Value); err != nil {
-			fmt.Println(err.Error())
+		filter, err := a.prompt("Search", "", func(string) error { return nil })
+		if err != nil {
+			return nil, err
+		}
+		page, err := s.source.source.Fetch(context.Background(), OptionQuery{Filter: filter, Limit: optionPageSize})
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Options) == 0 {
+			fmt.Fprintln(a.w, "No matches.")
 			continue
 		}
-		fmt.Println(styles.SelectedOption.Render("Chose: " + option.Key + "\n"))
-		s.accessor.Set(option.Value)
-		break
+		if page.Total > len(page.Options) {
+			fmt.Fprintf(a.w, "Showing the first %d of %d matches.\n", len(page.Options), page.Total)
+		}
+		return page.Options, nil
 	}
-
-	return nil
 }
 
 // WithTheme sets the theme of the select field.
@@ -692,6 +1042,8 @@ func (s *Select[T]) WithKeyMap(k *KeyMap) Field {
 	s.keymap.Right.SetEnabled(s.inline)
 	s.keymap.Up.SetEnabled(!s.inline)
 	s.keymap.Down.SetEnabled(!s.inline)
+	s.keymap.PreviewUp.SetEnabled(s.preview.enabled())
+	s.keymap.PreviewDown.SetEnabled(s.preview.enabled())
 	return s
 }
 
@@ -704,6 +1056,7 @@ func (s *Select[T]) WithAccessible(accessible bool) Field {
 // WithWidth sets the width of the select field.
 func (s *Select[T]) WithWidth(width int) Field {
 	s.width = width
+	s.updateViewportHeight()
 	return s
 }
 
@@ -730,3 +1083,57 @@ func (s *Select[T]) GetKey() string { return s.key }
 func (s *Select[T]) GetValue() any {
 	return s.accessor.Get()
 }
+
+// summarize returns the title and the key of the selected option for the
+// review page.
+func (s *Select[T]) summarize() (string, string) {
+	value := s.accessor.Get()
+	if s.source != nil {
+		if i := s.source.indexOf(value); i >= 0 {
+			return s.title.val, s.source.At(i).Key
+		}
+	} else if i := findOption(s.options.val, value); i >= 0 {
+		return s.title.val, s.options.val[i].Key
+	}
+	return s.title.val, fmt.Sprint(value)
+}
+
+// HoveredKey returns the key of the option under the cursor, or an empty
+// string if there is none.
+func (s *Select[T]) HoveredKey() string {
+	if s.selected < 0 || s.selected >= s.rows().Len() {
+		return ""
+	}
+	return s.rows().At(s.selected).Key
+}
+
+// setValue sets the value of the field from an answer, matching an option by
+// value or by key.
+func (s *Select[T]) setValue(value any) error {
+	i := findOption(s.options.val, value)
+	if i < 0 {
+		// Options may not have been computed or loaded yet, accept typed
+		// values as is.
+		v, ok := value.(T)
+		if !ok || len(s.options.val) > 0 {
+			return fmt.Errorf("%v is not one of the options", value)
+		}
+		if err := withAsyncValidation(s.validate, s.validateAsync)(v); err != nil {
+			return err
+		}
+		s.accessor.Set(v)
+		s.selectValue(v)
+		return nil
+	}
+	if s.options.val[i].disabled {
+		return errOptionDisabled(s.options.val[i])
+	}
+	v := s.options.val[i].Value
+	if err := withAsyncValidation(s.validate, s.validateAsync)(v); err != nil {
+		return err
+	}
+	s.clearFilter()
+	s.selected = i
+	s.accessor.Set(v)
+	return nil
+}
@@ -1,7 +1,9 @@
 package huh
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"strings"
@@ -9,7 +11,6 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/textarea"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/thedeveloper-sharath/huh/accessibility"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -19,6 +20,8 @@ import (
 // it to gather longer-form user input. The Text field can be filled with an
 // EDITOR.
 type Text struct {
+	fieldHide
+
 	accessor Accessor[string]
 	key      string
 	id       int
@@ -33,9 +36,10 @@ type Text struct {
 
 	textarea textarea.Model
 
-	focused  bool
-	validate func(string) error
-	err      error
+	focused       bool
+	validate      func(string) error
+	validateAsync *asyncValidation[string]
+	err           error
 
 	accessible bool
 	width      int
@@ -180,6 +184,16 @@ func (t *Text) Validate(validate func(string) error) *Text {
 	return t
 }
 
+// ValidateAsync sets the asynchronous validation function of the text field.
+//
+// The function runs as a command once the user stops typing, after the
+// synchronous validation passes, and its context is cancelled when the value
+// changes again. The field can't be submitted until the validation resolves.
+func (t *Text) ValidateAsync(validate func(context.Context, string) error) *Text {
+	t.validateAsync = newAsyncValidation(t.id, validate)
+	return t
+}
+
 const defaultEditor = "nano"
 
 // getEditor returns the editor command and arguments.
@@ -212,10 +226,29 @@ func (t *Text) EditorExtension(extension string) *Text {
 }
 
 // Error returns the error of the text field.
-func (t *Text) Error() error { return t.err }
+func (t *Text) Error() error {
+	if t.err != nil {
+		return t.err
+	}
+	return t.validateAsync.error()
+}
 
 // Skip returns whether the textarea should be skipped or should be blocking.
-func (*Text) Skip() bool { return false }
+// Hidden textareas are skipped.
+func (t *Text) Skip() bool { return t.hidden() }
+
+// WithHideFunc sets the function that checks if the textarea should be hidden.
+func (t *Text) WithHideFunc(hideFunc func() bool) *Text {
+	t.setHideFunc(hideFunc)
+	return t
+}
+
+// WithShowWhen sets the function that checks if the textarea should be shown.
+// It is the opposite of WithHideFunc.
+func (t *Text) WithShowWhen(showFunc func() bool) *Text {
+	t.setShowWhen(showFunc)
+	return t
+}
 
 // Zoom returns whether the note should be zoomed.
 func (*Text) Zoom() bool { return false }
@@ -223,7 +256,7 @@ func (*Text) Zoom() bool { return false }
 // Focus focuses the text field.
 func (t *Text) Focus() tea.Cmd {
 	t.focused = true
-	return t.textarea.Focus()
+	return tea.Batch(t.textarea.Focus(), t.validateAsync.init(t.textarea.Value()))
 }
 
 // Blur blurs the text field.
@@ -253,6 +286,12 @@ func (t *Text) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 	var cmd tea.Cmd
 
+	if cmd := t.validateAsync.update(msg); cmd != nil {
+		return t, cmd
+	}
+
+	before := t.textarea.Value()
+
 	switch msg := msg.(type) {
 	case updateValueMsg:
 		t.textarea.SetValue(string(msg))
@@ -320,14 +359,14 @@ func (t *Text) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, t.keymap.Next, t.keymap.Submit):
 			value := t.textarea.Value()
 			t.err = t.validate(value)
-			if t.err != nil {
+			if t.err != nil || t.validateAsync.blocked() {
 				return t, nil
 			}
 			cmds = append(cmds, NextField)
 		case key.Matches(msg, t.keymap.Prev):
 			value := t.textarea.Value()
 			t.err = t.validate(value)
-			if t.err != nil {
+			if t.err != nil || t.validateAsync.blocked() {
 				return t, nil
 			}
 			cmds = append(cmds, PrevField)
@@ -337,6 +376,13 @@ func (t *Text) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	t.textarea, cmd = t.textarea.Update(msg)
 	cmds = append(cmds, cmd)
 	t.accessor.Set(t.textarea.Value())
+	if value := t.textarea.Value(); value != before {
+		if t.validate(value) == nil {
+			cmds = append(cmds, t.validateAsync.schedule(value))
+		} else {
+			t.validateAsync.reset()
+		}
+	}
 
 	return t, tea.Batch(cmds...)
 }
@@ -380,13 +426,14 @@ func (t *Text) View() string {
 	var sb strings.Builder
 	if t.title.val != "" || t.title.fn != nil {
 		sb.WriteString(styles.Title.Render(t.title.val))
-		if t.err != nil {
+		sb.WriteString(t.validateAsync.view(styles))
+		if t.Error() != nil {
 			sb.WriteString(styles.ErrorIndicator.String())
 		}
 		sb.WriteString("\n")
 	}
 	if t.description.val != "" || t.description.fn != nil {
-		sb.WriteString(styles.Description.Render(t.description.val))
+		sb.WriteString(markdownDescription(t.theme, styles, t.description.val, t.width))
 		sb.WriteString("\n")
 	}
 	sb.WriteString(t.textarea.View())
@@ -397,28 +444,35 @@ func (t *Text) View() string {
 // Run runs the text field.
 func (t *Text) Run() error {
 	if t.accessible {
-		return t.runAccessible()
+		return t.RunAccessible(os.Stdout, os.Stdin)
 	}
 	return Run(t)
 }
 
-// runAccessible runs an accessible text field.
-func (t *Text) runAccessible() error {
-	styles := t.activeStyles()
-	fmt.Println(styles.Title.Render(t.title.val))
-	fmt.Println()
-	t.accessor.Set(accessibility.PromptString("Input: ", func(input string) error {
+// RunAccessible runs an accessible text field, reading answers from r and
+// writing prompts to w.
+func (t *Text) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	var rules []string
+	if t.textarea.CharLimit > 0 {
+		rules = append(rules, fmt.Sprintf("Up to %d characters.", t.textarea.CharLimit))
+	}
+	a.announce(t.title.val, plainDescription(t.theme, t.description.val), rules...)
+
+	value, err := a.prompt("Input", t.accessor.Get(), func(input string) error {
 		if err := t.validate(input); err != nil {
-			// Handle the error from t.validate, return it
 			return err
 		}
-
-		if len(input) > t.textarea.CharLimit {
-			return fmt.Errorf("Input cannot exceed %d characters", t.textarea.CharLimit)
+		if limit := t.textarea.CharLimit; limit > 0 && len([]rune(input)) > limit {
+			return fmt.Errorf("must be at most %d characters", limit)
 		}
-		return nil
-	}))
-	fmt.Println()
+		return t.validateAsync.run(input)
+	})
+	if err != nil {
+		return err
+	}
+	t.accessor.Set(value)
+	a.answer(value)
 	return nil
 }
 
@@ -479,3 +533,20 @@ func (t *Text) GetKey() string { return t.key }
 func (t *Text) GetValue() any {
 	return t.accessor.Get()
 }
+
+// summarize returns the title and the answer of the text field for the
+// review page.
+func (t *Text) summarize() (string, string) {
+	return t.title.val, t.accessor.Get()
+}
+
+// setValue sets the value of the field from an answer.
+func (t *Text) setValue(value any) error {
+	s := answerString(value)
+	if err := withAsyncValidation(t.validate, t.validateAsync)(s); err != nil {
+		return err
+	}
+	t.accessor.Set(s)
+	t.textarea.SetValue(s)
+	return nil
+}
@@ -0,0 +1,1170 @@
+package huh
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// TreeNode is a node of a tree select field, with a key to display, a value
+// and children.
+//
+// Children can also be loaded the first time the node is expanded, see
+// ChildrenFunc.
+type TreeNode[T comparable] struct {
+	Key      string
+	Value    T
+	Children []*TreeNode[T]
+
+	load     func() ([]*TreeNode[T], error)
+	parent   *TreeNode[T]
+	expanded bool
+	loading  bool
+	selected bool
+}
+
+// NewTreeNode returns a new tree node with the given key, value and children.
+func NewTreeNode[T comparable](key string, value T, children ...*TreeNode[T]) *TreeNode[T] {
+	return &TreeNode[T]{Key: key, Value: value, Children: children}
+}
+
+// ChildrenFunc sets the function loading the children of the node, such as
+// the teams of an organization fetched from an API. It is called in a command
+// the first time the node is expanded or the tree is filtered, or when an
+// answer or accessible mode needs every node.
+func (n *TreeNode[T]) ChildrenFunc(load func() ([]*TreeNode[T], error)) *TreeNode[T] {
+	n.load = load
+	return n
+}
+
+// Expanded sets whether the children of the node are shown initially.
+func (n *TreeNode[T]) Expanded(expanded bool) *TreeNode[T] {
+	n.expanded = expanded
+	return n
+}
+
+// Selected sets whether the node is selected initially, which selects its
+// children as well. It only applies to tree select fields allowing multiple
+// choices.
+func (n *TreeNode[T]) Selected(selected bool) *TreeNode[T] {
+	n.setSelected(selected)
+	return n
+}
+
+// String returns the key of the node.
+func (n *TreeNode[T]) String() string { return n.Key }
+
+// isLeaf returns whether the node has no children and none to load.
+func (n *TreeNode[T]) isLeaf() bool { return len(n.Children) == 0 && n.load == nil }
+
+// treeState is the selection state of a tree node.
+type treeState int
+
+const (
+	treeUnselected treeState = iota
+	treePartial
+	treeSelected
+)
+
+// state returns the selection state of the node. A node with children is
+// selected when all of its children are, and partially selected when only
+// some of its descendants are.
+func (n *TreeNode[T]) state() treeState {
+	if len(n.Children) == 0 {
+		if n.selected {
+			return treeSelected
+		}
+		return treeUnselected
+	}
+	var selected, partial int
+	for _, child := range n.Children {
+		switch child.state() {
+		case treeSelected:
+			selected++
+		case treePartial:
+			partial++
+		}
+	}
+	switch {
+	case selected == len(n.Children):
+		return treeSelected
+	case selected > 0 || partial > 0:
+		return treePartial
+	}
+	return treeUnselected
+}
+
+// setSelected selects or deselects the node and its descendants.
+func (n *TreeNode[T]) setSelected(selected bool) {
+	n.selected = selected
+	for _, child := range n.Children {
+		child.setSelected(selected)
+	}
+}
+
+// path returns the keys from the root of the tree to the node.
+func (n *TreeNode[T]) path() string {
+	if n.parent == nil {
+		return n.Key
+	}
+	return n.parent.path() + " / " + n.Key
+}
+
+// linkTree sets the parent of the given nodes and of their descendants.
+func linkTree[T comparable](parent *TreeNode[T], nodes []*TreeNode[T]) {
+	for _, n := range nodes {
+		n.parent = parent
+		linkTree(n, n.Children)
+	}
+}
+
+// walkTree calls fn for each node of the tree in order, stopping at the first
+// node for which fn returns false.
+func walkTree[T comparable](nodes []*TreeNode[T], fn func(*TreeNode[T]) bool) bool {
+	for _, n := range nodes {
+		if !fn(n) || !walkTree(n.Children, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// treeRow is a node shown on a row of a tree select field.
+type treeRow[T comparable] struct {
+	node  *TreeNode[T]
+	depth int
+	open  bool
+}
+
+// treeChildrenMsg is sent when the children of a tree node were loaded.
+type treeChildrenMsg[T comparable] struct {
+	id       int
+	node     *TreeNode[T]
+	children []*TreeNode[T]
+	err      error
+}
+
+// TreeSelect is a select field for hierarchical choices, such as an
+// organization, team and service, shown as a tree of nodes that expand and
+// collapse.
+//
+// Only nodes without children can be chosen, the others group them: choosing
+// a group expands it instead.
+//
+// The filter matches nodes across the whole tree, loading the children of
+// every node first, and shows them along with their ancestors. With Multiple,
+// several nodes can be selected, groups included, and nodes whose descendants
+// are only partly selected are marked as such.
+type TreeSelect[T comparable] struct {
+	fieldHide
+
+	id       int
+	accessor Accessor[T]
+	values   Accessor[[]T]
+	key      string
+
+	viewport viewport.Model
+
+	title       string
+	description string
+	nodes       []*TreeNode[T]
+	rows        []treeRow[T]
+
+	multiple       bool
+	validate       func(T) error
+	validateValues func([]T) error
+	err            error
+
+	cursor    int
+	focused   bool
+	filtering bool
+	filter    textinput.Model
+	filterFn  FilterFunc
+
+	width      int
+	height     int
+	accessible bool
+	theme      *Theme
+	keymap     TreeSelectKeyMap
+}
+
+// NewTreeSelect creates a new tree select field.
+func NewTreeSelect[T comparable]() *TreeSelect[T] {
+	filter := textinput.New()
+	filter.Prompt = "/"
+
+	return &TreeSelect[T]{
+		id:             nextID(),
+		accessor:       &EmbeddedAccessor[T]{},
+		values:         &EmbeddedAccessor[[]T]{},
+		validate:       func(T) error { return nil },
+		validateValues: func([]T) error { return nil },
+		filter:         filter,
+		filterFn:       FilterContains,
+	}
+}
+
+// Nodes sets the top-level nodes of the tree.
+func (t *TreeSelect[T]) Nodes(nodes ...*TreeNode[T]) *TreeSelect[T] {
+	t.nodes = nodes
+	linkTree(nil, nodes)
+	t.cursor = 0
+	if t.multiple {
+		t.selectValues(t.values.Get())
+	}
+	t.selectValue(t.accessor.Get())
+	return t
+}
+
+// Value sets the value of the tree select field.
+func (t *TreeSelect[T]) Value(value *T) *TreeSelect[T] {
+	return t.Accessor(NewPointerAccessor(value))
+}
+
+// Accessor sets the accessor of the tree select field.
+func (t *TreeSelect[T]) Accessor(accessor Accessor[T]) *TreeSelect[T] {
+	t.accessor = accessor
+	t.selectValue(accessor.Get())
+	return t
+}
+
+// Values sets the values of a tree select field allowing multiple choices,
+// and allows them.
+func (t *TreeSelect[T]) Values(values *[]T) *TreeSelect[T] {
+	return t.ValuesAccessor(NewPointerAccessor(values))
+}
+
+// ValuesAccessor sets the accessor of the values of a tree select field
+// allowing multiple choices, and allows them.
+func (t *TreeSelect[T]) ValuesAccessor(accessor Accessor[[]T]) *TreeSelect[T] {
+	t.values = accessor
+	t.Multiple(true)
+	t.selectValues(accessor.Get())
+	return t
+}
+
+// Multiple sets whether several nodes can be selected. The selected nodes
+// are set with Values.
+func (t *TreeSelect[T]) Multiple(multiple bool) *TreeSelect[T] {
+	t.multiple = multiple
+	t.keymap.Toggle.SetEnabled(multiple)
+	return t
+}
+
+// selectValues selects the nodes with the given values.
+func (t *TreeSelect[T]) selectValues(values []T) {
+	selected := make(map[T]bool)
+	for _, v := range values {
+		selected[v] = true
+	}
+	walkTree(t.nodes, func(n *TreeNode[T]) bool {
+		if selected[n.Value] {
+			n.setSelected(true)
+		}
+		return true
+	})
+}
+
+// selectValue expands the ancestors of the node with the given value and
+// moves the cursor to it.
+func (t *TreeSelect[T]) selectValue(value T) {
+	walkTree(t.nodes, func(n *TreeNode[T]) bool {
+		if n.Value != value {
+			return true
+		}
+		for p := n.parent; p != nil; p = p.parent {
+			p.expanded = true
+		}
+		t.buildRows()
+		for i, row := range t.rows {
+			if row.node == n {
+				t.cursor = i
+			}
+		}
+		return false
+	})
+	t.refresh()
+}
+
+// Key sets the key of the tree select field which can be used to retrieve
+// the value after submission.
+func (t *TreeSelect[T]) Key(key string) *TreeSelect[T] {
+	t.key = key
+	return t
+}
+
+// Title sets the title of the tree select field.
+func (t *TreeSelect[T]) Title(title string) *TreeSelect[T] {
+	t.title = title
+	return t
+}
+
+// Description sets the description of the tree select field.
+func (t *TreeSelect[T]) Description(description string) *TreeSelect[T] {
+	t.description = description
+	return t
+}
+
+// Filter sets the func used to match nodes against the filter.
+//
+// By default nodes are matched with FilterContains, which a nil func
+// restores.
+func (t *TreeSelect[T]) Filter(fn FilterFunc) *TreeSelect[T] {
+	if fn == nil {
+		fn = FilterContains
+	}
+	t.filterFn = fn
+	return t
+}
+
+// Height sets the height of the tree select field. If the nodes exceed the
+// height, the tree becomes scrollable.
+func (t *TreeSelect[T]) Height(height int) *TreeSelect[T] {
+	t.height = height
+	t.updateViewportHeight()
+	return t
+}
+
+// Validate sets the validation function of the tree select field.
+func (t *TreeSelect[T]) Validate(validate func(T) error) *TreeSelect[T] {
+	t.validate = validate
+	return t
+}
+
+// ValidateValues sets the validation function of the values of a tree select
+// field allowing multiple choices.
+func (t *TreeSelect[T]) ValidateValues(validate func([]T) error) *TreeSelect[T] {
+	t.validateValues = validate
+	return t
+}
+
+// Error returns the error of the tree select field.
+func (t *TreeSelect[T]) Error() error { return t.err }
+
+// Skip returns whether the tree select should be skipped or should be
+// blocking. Hidden tree selects are skipped.
+func (t *TreeSelect[T]) Skip() bool { return t.hidden() }
+
+// WithHideFunc sets the function that checks if the tree select should be
+// hidden.
+func (t *TreeSelect[T]) WithHideFunc(hideFunc func() bool) *TreeSelect[T] {
+	t.setHideFunc(hideFunc)
+	return t
+}
+
+// WithShowWhen sets the function that checks if the tree select should be
+// shown. It is the opposite of WithHideFunc.
+func (t *TreeSelect[T]) WithShowWhen(showFunc func() bool) *TreeSelect[T] {
+	t.setShowWhen(showFunc)
+	return t
+}
+
+// Zoom returns whether the tree select should be zoomed.
+func (*TreeSelect[T]) Zoom() bool { return false }
+
+// Focus focuses the tree select field.
+func (t *TreeSelect[T]) Focus() tea.Cmd {
+	t.focused = true
+	return nil
+}
+
+// Blur blurs the tree select field.
+func (t *TreeSelect[T]) Blur() tea.Cmd {
+	t.focused = false
+	t.err = t.validateValue()
+	return nil
+}
+
+// KeyBinds returns the help keybindings for the tree select field.
+func (t *TreeSelect[T]) KeyBinds() []key.Binding {
+	return []key.Binding{
+		t.keymap.Toggle,
+		t.keymap.Up,
+		t.keymap.Down,
+		t.keymap.Expand,
+		t.keymap.Collapse,
+		t.keymap.Filter,
+		t.keymap.SetFilter,
+		t.keymap.ClearFilter,
+		t.keymap.Prev,
+		t.keymap.Next,
+		t.keymap.Submit,
+	}
+}
+
+// Init initializes the tree select field.
+func (t *TreeSelect[T]) Init() tea.Cmd {
+	return nil
+}
+
+// Update updates the tree select field.
+func (t *TreeSelect[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	t.updateViewportHeight()
+
+	var cmd tea.Cmd
+	if t.filtering {
+		t.filter, cmd = t.filter.Update(msg)
+	}
+
+	switch msg := msg.(type) {
+	case treeChildrenMsg[T]:
+		if msg.id == t.id && msg.node.loading {
+			t.setChildren(msg.node, msg.children, msg.err)
+			if t.filtering || t.filter.Value() != "" {
+				cmd = tea.Batch(cmd, t.loadChildren())
+			}
+		}
+	case tea.KeyMsg:
+		t.err = nil
+		switch {
+		case key.Matches(msg, t.keymap.Filter):
+			t.setFiltering(true)
+			return t, tea.Batch(t.filter.Focus(), t.loadChildren())
+		case key.Matches(msg, t.keymap.SetFilter):
+			if len(t.rows) == 0 {
+				t.filter.SetValue("")
+			}
+			t.setFiltering(false)
+		case key.Matches(msg, t.keymap.ClearFilter):
+			t.clearFilter()
+		case key.Matches(msg, t.keymap.Up):
+			// When filtering we should ignore j/k keybindings, see the
+			// select field.
+			if t.filtering && msg.String() == "k" {
+				break
+			}
+			t.moveCursor(t.cursor - 1)
+		case key.Matches(msg, t.keymap.Down):
+			if t.filtering && msg.String() == "j" {
+				break
+			}
+			t.moveCursor(t.cursor + 1)
+		case key.Matches(msg, t.keymap.GotoTop):
+			if t.filtering {
+				break
+			}
+			t.moveCursor(0)
+		case key.Matches(msg, t.keymap.GotoBottom):
+			if t.filtering {
+				break
+			}
+			t.moveCursor(len(t.rows) - 1)
+		case key.Matches(msg, t.keymap.HalfPageUp):
+			t.moveCursor(max(t.cursor-t.viewport.Height/2, 0))
+		case key.Matches(msg, t.keymap.HalfPageDown):
+			t.moveCursor(min(t.cursor+t.viewport.Height/2, len(t.rows)-1))
+		case key.Matches(msg, t.keymap.Expand):
+			if t.filtering && msg.String() == "l" {
+				break
+			}
+			return t, tea.Batch(cmd, t.expand())
+		case key.Matches(msg, t.keymap.Collapse):
+			if t.filtering && msg.String() == "h" {
+				break
+			}
+			t.collapse()
+		case key.Matches(msg, t.keymap.Toggle) && !t.filtering:
+			if node := t.hovered(); node != nil {
+				node.setSelected(node.state() != treeSelected)
+				t.updateValue()
+			}
+		case key.Matches(msg, t.keymap.Prev):
+			t.err = t.validateValue()
+			if t.err != nil {
+				return t, nil
+			}
+			return t, PrevField
+		case key.Matches(msg, t.keymap.Next, t.keymap.Submit):
+			if t.filtering && len(t.rows) == 0 {
+				break
+			}
+			if node := t.hovered(); !t.multiple && node != nil && !node.isLeaf() {
+				return t, tea.Batch(cmd, t.expand())
+			}
+			t.setFiltering(false)
+			t.err = t.validateValue()
+			if t.err != nil {
+				return t, nil
+			}
+			return t, NextField
+		}
+
+		if t.filtering {
+			t.refresh()
+		}
+	}
+
+	return t, cmd
+}
+
+// hovered returns the node under the cursor, or nil if there is none.
+func (t *TreeSelect[T]) hovered() *TreeNode[T] {
+	if t.cursor < 0 || t.cursor >= len(t.rows) {
+		return nil
+	}
+	return t.rows[t.cursor].node
+}
+
+// moveCursor moves the cursor to the given row, wrapping around the ends of
+// the tree.
+func (t *TreeSelect[T]) moveCursor(i int) {
+	if len(t.rows) == 0 {
+		return
+	}
+	switch {
+	case i < 0:
+		i = len(t.rows) - 1
+	case i >= len(t.rows):
+		i = 0
+	}
+	t.cursor = i
+	t.keepInView()
+	t.updateValue()
+}
+
+// expand shows the children of the node under the cursor, loading them if
+// needed. The cursor moves to the first child of a node already expanded.
+func (t *TreeSelect[T]) expand() tea.Cmd {
+	node := t.hovered()
+	if node == nil || node.isLeaf() || node.loading {
+		return nil
+	}
+	if t.rows[t.cursor].open && len(node.Children) > 0 {
+		t.moveCursor(t.cursor + 1)
+		return nil
+	}
+	node.expanded = true
+	if node.load != nil {
+		return t.load(node)
+	}
+	t.refresh()
+	return nil
+}
+
+// load returns the command loading the children of a node.
+func (t *TreeSelect[T]) load(node *TreeNode[T]) tea.Cmd {
+	node.loading = true
+	load := node.load
+	return func() tea.Msg {
+		children, err := load()
+		return treeChildrenMsg[T]{id: t.id, node: node, children: children, err: err}
+	}
+}
+
+// loadChildren returns the commands loading the children of every node that
+// loads them lazily, so that the filter searches the whole tree. Children
+// loaded in turn are loaded as they arrive.
+func (t *TreeSelect[T]) loadChildren() tea.Cmd {
+	var cmds []tea.Cmd
+	walkTree(t.nodes, func(n *TreeNode[T]) bool {
+		if n.load != nil && !n.loading {
+			cmds = append(cmds, t.load(n))
+		}
+		return true
+	})
+	return tea.Batch(cmds...)
+}
+
+// collapse hides the children of the node under the cursor, or moves the
+// cursor to the parent of a collapsed node.
+func (t *TreeSelect[T]) collapse() {
+	node := t.hovered()
+	if node == nil {
+		return
+	}
+	if node.expanded && !node.isLeaf() && t.filter.Value() == "" {
+		node.expanded = false
+		t.refresh()
+		return
+	}
+	for i, row := range t.rows {
+		if node.parent != nil && row.node == node.parent {
+			t.moveCursor(i)
+		}
+	}
+}
+
+// setChildren sets the loaded children of a node. Children of a selected
+// node are selected too.
+func (t *TreeSelect[T]) setChildren(node *TreeNode[T], children []*TreeNode[T], err error) {
+	node.loading = false
+	if err != nil {
+		node.expanded = false
+		t.err = fmt.Errorf("loading %s: %w", node.Key, err)
+		return
+	}
+	node.load = nil
+	node.Children = children
+	linkTree(node, children)
+	if node.selected {
+		node.setSelected(true)
+	}
+	t.refresh()
+}
+
+// buildRows sets the rows from the expanded nodes. While filtering, the
+// nodes matching the filter are shown along with their ancestors.
+func (t *TreeSelect[T]) buildRows() {
+	filter := t.filter.Value()
+	rows := make([]treeRow[T], 0, len(t.rows))
+	var add func(nodes []*TreeNode[T], depth int)
+	add = func(nodes []*TreeNode[T], depth int) {
+		for _, n := range nodes {
+			if filter != "" {
+				if !t.matches(n, filter) {
+					continue
+				}
+				open := false
+				for _, child := range n.Children {
+					open = open || t.matches(child, filter)
+				}
+				rows = append(rows, treeRow[T]{node: n, depth: depth, open: open})
+				add(n.Children, depth+1)
+				continue
+			}
+			rows = append(rows, treeRow[T]{node: n, depth: depth, open: n.expanded && !n.loading})
+			if n.expanded {
+				add(n.Children, depth+1)
+			}
+		}
+	}
+	add(t.nodes, 0)
+	t.rows = rows
+}
+
+// matches returns whether the node or one of its descendants matches the
+// filter.
+func (t *TreeSelect[T]) matches(n *TreeNode[T], filter string) bool {
+	if _, ok := t.filterFn(filter, n.Key); ok {
+		return true
+	}
+	for _, child := range n.Children {
+		if t.matches(child, filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// refresh rebuilds the rows, keeping the cursor on the same node if it is
+// still shown.
+func (t *TreeSelect[T]) refresh() {
+	node := t.hovered()
+	t.buildRows()
+	t.cursor = clamp(t.cursor, 0, len(t.rows)-1)
+	for i, row := range t.rows {
+		if row.node == node {
+			t.cursor = i
+			break
+		}
+	}
+	t.updateViewportHeight()
+	t.keepInView()
+	t.updateValue()
+}
+
+// updateValue sets the value to the node under the cursor, unless it groups
+// other nodes, or the values to the selected nodes when multiple choices are
+// allowed.
+func (t *TreeSelect[T]) updateValue() {
+	if t.multiple {
+		t.values.Set(t.selectedValues())
+		return
+	}
+	if node := t.hovered(); node != nil && node.isLeaf() {
+		t.accessor.Set(node.Value)
+	}
+}
+
+// selectedValues returns the values of the selected nodes in tree order.
+func (t *TreeSelect[T]) selectedValues() []T {
+	values := make([]T, 0)
+	walkTree(t.nodes, func(n *TreeNode[T]) bool {
+		if n.state() == treeSelected {
+			values = append(values, n.Value)
+		}
+		return true
+	})
+	return values
+}
+
+// validateValue validates the value, or the values when multiple choices are
+// allowed.
+func (t *TreeSelect[T]) validateValue() error {
+	if t.multiple {
+		return t.validateValues(t.values.Get())
+	}
+	return t.validate(t.accessor.Get())
+}
+
+// keepInView scrolls the viewport so that the cursor is in view.
+func (t *TreeSelect[T]) keepInView() {
+	t.viewport.SetContent(t.nodesView())
+	if t.cursor < t.viewport.YOffset {
+		t.viewport.SetYOffset(t.cursor)
+	} else if t.cursor >= t.viewport.YOffset+t.viewport.Height {
+		t.viewport.SetYOffset(t.cursor - t.viewport.Height + 1)
+	}
+}
+
+// updateViewportHeight updates the viewport size according to the Height
+// setting on this tree select field.
+func (t *TreeSelect[T]) updateViewportHeight() {
+	// If no height is set size the viewport to fit the whole tree, so that
+	// expanding nodes doesn't change the height of the field.
+	if t.height <= 0 {
+		var n int
+		walkTree(t.nodes, func(*TreeNode[T]) bool {
+			n++
+			return true
+		})
+		t.viewport.Height = clamp(n, minHeight, defaultHeight)
+		return
+	}
+
+	t.viewport.Height = max(minHeight, t.height-
+		lipgloss.Height(t.titleView())-
+		lipgloss.Height(t.descriptionView()))
+}
+
+func (t *TreeSelect[T]) activeStyles() *FieldStyles {
+	theme := t.theme
+	if theme == nil {
+		theme = ThemeCharm()
+	}
+	if t.focused {
+		return &theme.Focused
+	}
+	return &theme.Blurred
+}
+
+func (t *TreeSelect[T]) titleView() string {
+	var (
+		styles = t.activeStyles()
+		sb     = strings.Builder{}
+	)
+	if t.filtering {
+		sb.WriteString(t.filter.View())
+	} else if t.filter.Value() != "" {
+		sb.WriteString(styles.Title.Render(t.title) + styles.Description.Render("/"+t.filter.Value()))
+	} else {
+		sb.WriteString(styles.Title.Render(t.title))
+	}
+	if t.err != nil {
+		sb.WriteString(styles.ErrorIndicator.String())
+	}
+	return sb.String()
+}
+
+func (t *TreeSelect[T]) descriptionView() string {
+	return markdownDescription(t.theme, t.activeStyles(), t.description, t.width)
+}
+
+// nodesView renders the rows of the tree, indenting nodes by depth.
+func (t *TreeSelect[T]) nodesView() string {
+	var (
+		styles = t.activeStyles()
+		c      = styles.SelectSelector.String()
+		leaf   = strings.Repeat(" ", lipgloss.Width(styles.CollapsedNode.String()))
+		sb     strings.Builder
+	)
+
+	if len(t.rows) == 0 && t.filter.Value() != "" {
+		return styles.TextInput.Placeholder.Render("No matches")
+	}
+
+	for i, row := range t.rows {
+		if t.cursor == i {
+			sb.WriteString(c)
+		} else {
+			sb.WriteString(strings.Repeat(" ", lipgloss.Width(c)))
+		}
+		sb.WriteString(strings.Repeat(" ", row.depth*lipgloss.Width(leaf)))
+
+		switch {
+		case row.node.isLeaf():
+			sb.WriteString(leaf)
+		case row.open:
+			sb.WriteString(styles.ExpandedNode.String())
+		default:
+			sb.WriteString(styles.CollapsedNode.String())
+		}
+
+		style := styles.UnselectedOption
+		if t.multiple {
+			switch row.node.state() {
+			case treeSelected:
+				sb.WriteString(styles.SelectedPrefix.String())
+				style = styles.SelectedOption
+			case treePartial:
+				sb.WriteString(styles.PartialPrefix.String())
+			default:
+				sb.WriteString(styles.UnselectedPrefix.String())
+			}
+		} else if t.cursor == i {
+			style = styles.SelectedOption
+		}
+		sb.WriteString(highlightMatches(row.node.Key, t.filter.Value(), t.filterFn, style, styles.MatchHighlight))
+
+		if row.node.loading {
+			sb.WriteString(" " + styles.TextInput.Placeholder.Render("Loading..."))
+		}
+		if i < len(t.rows)-1 {
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+// View renders the tree select field.
+func (t *TreeSelect[T]) View() string {
+	styles := t.activeStyles()
+	t.updateViewportHeight()
+	t.viewport.SetContent(t.nodesView())
+
+	var sb strings.Builder
+	if t.title != "" {
+		sb.WriteString(t.titleView())
+		sb.WriteString("\n")
+	}
+	if t.description != "" {
+		sb.WriteString(t.descriptionView())
+		sb.WriteString("\n")
+	}
+	sb.WriteString(t.viewport.View())
+	return styles.Base.Render(sb.String())
+}
+
+// clearFilter clears the value of the filter.
+func (t *TreeSelect[T]) clearFilter() {
+	t.filter.SetValue("")
+	t.setFiltering(false)
+	t.refresh()
+}
+
+// setFiltering sets the filter of the tree select field.
+func (t *TreeSelect[T]) setFiltering(filtering bool) {
+	t.filtering = filtering
+	t.keymap.SetFilter.SetEnabled(filtering)
+	t.keymap.Filter.SetEnabled(!filtering)
+	t.keymap.ClearFilter.SetEnabled(!filtering && t.filter.Value() != "")
+}
+
+// Run runs the tree select field.
+func (t *TreeSelect[T]) Run() error {
+	if t.accessible {
+		return t.RunAccessible(os.Stdout, os.Stdin)
+	}
+	return Run(t)
+}
+
+// RunAccessible runs an accessible tree select field, reading answers from r
+// and writing prompts to w.
+//
+// Children that are loaded lazily are all loaded first, and every node is
+// listed with its path from the top of the tree.
+func (t *TreeSelect[T]) RunAccessible(w io.Writer, r io.Reader) error {
+	a := newAccessibleRenderer(w, r)
+	if err := t.loadAll(); err != nil {
+		return err
+	}
+
+	var nodes []*TreeNode[T]
+	walkTree(t.nodes, func(n *TreeNode[T]) bool {
+		nodes = append(nodes, n)
+		return true
+	})
+	if len(nodes) == 0 {
+		a.announce(t.title, plainDescription(t.theme, t.description))
+		fmt.Fprintln(w, "There are no options.")
+		fmt.Fprintln(w)
+		return nil
+	}
+
+	if t.multiple {
+		return t.runAccessibleMultiple(a, nodes)
+	}
+
+	leaves := nodes[:0]
+	for _, n := range nodes {
+		if n.isLeaf() {
+			leaves = append(leaves, n)
+		}
+	}
+	nodes = leaves
+
+	a.announce(t.title, plainDescription(t.theme, t.description), "Enter the number of an option.")
+	var current string
+	for i, n := range nodes {
+		fmt.Fprintf(w, "%d. %s\n", i+1, plain(n.path()))
+		if current == "" && n.Value == t.accessor.Get() {
+			current = strconv.Itoa(i + 1)
+		}
+	}
+
+	input, err := a.prompt("Choose", current, func(input string) error {
+		if err := validChoice(1, len(nodes))(input); err != nil {
+			return err
+		}
+		i, _ := strconv.Atoi(input)
+		return t.validate(nodes[i-1].Value)
+	})
+	if err != nil {
+		return err
+	}
+	i, _ := strconv.Atoi(input)
+	t.accessor.Set(nodes[i-1].Value)
+	a.answer(nodes[i-1].path())
+	return nil
+}
+
+// runAccessibleMultiple selects and deselects nodes by number until the user
+// is done.
+func (t *TreeSelect[T]) runAccessibleMultiple(a *accessibleRenderer, nodes []*TreeNode[T]) error {
+	a.announce(t.title, plainDescription(t.theme, t.description), "Enter the number of an option to select or deselect it, or 0 when done.")
+	t.printNodes(a.w, nodes)
+
+	for {
+		input, err := a.prompt("Select", "0", validChoice(0, len(nodes)))
+		if err != nil {
+			return err
+		}
+		choice, _ := strconv.Atoi(input)
+		if choice == 0 {
+			t.updateValue()
+			if err := t.validateValues(t.values.Get()); err != nil {
+				a.reject(err)
+				continue
+			}
+			break
+		}
+
+		node := nodes[choice-1]
+		node.setSelected(node.state() != treeSelected)
+		if node.selected {
+			fmt.Fprintf(a.w, "Selected: %s\n", plain(node.path()))
+		} else {
+			fmt.Fprintf(a.w, "Deselected: %s\n", plain(node.path()))
+		}
+		t.printNodes(a.w, nodes)
+	}
+
+	_, answer := t.summarize()
+	if answer == "" {
+		answer = "none"
+	}
+	a.answer(answer)
+	return nil
+}
+
+// printNodes prints the numbered nodes with their selection state.
+func (t *TreeSelect[T]) printNodes(w io.Writer, nodes []*TreeNode[T]) {
+	for i, n := range nodes {
+		state := "not selected"
+		switch n.state() {
+		case treeSelected:
+			state = "selected"
+		case treePartial:
+			state = "partly selected"
+		}
+		fmt.Fprintf(w, "%d. %s (%s)\n", i+1, plain(n.path()), state)
+	}
+}
+
+// loadAll loads the children of every node that loads them lazily.
+func (t *TreeSelect[T]) loadAll() error {
+	var err error
+	walkTree(t.nodes, func(n *TreeNode[T]) bool {
+		if n.load == nil {
+			return true
+		}
+		var children []*TreeNode[T]
+		if children, err = n.load(); err != nil {
+			err = fmt.Errorf("loading %s: %w", n.Key, err)
+			return false
+		}
+		n.loading = true
+		t.setChildren(n, children, nil)
+		return true
+	})
+	return err
+}
+
+// WithTheme sets the theme of the tree select field.
+func (t *TreeSelect[T]) WithTheme(theme *Theme) Field {
+	if t.theme != nil {
+		return t
+	}
+	t.theme = theme
+	t.filter.Cursor.Style = theme.Focused.TextInput.Cursor
+	t.filter.Cursor.TextStyle = theme.Focused.TextInput.CursorText
+	t.filter.PromptStyle = theme.Focused.TextInput.Prompt
+	t.filter.TextStyle = theme.Focused.TextInput.Text
+	t.filter.PlaceholderStyle = theme.Focused.TextInput.Placeholder
+	t.updateViewportHeight()
+	return t
+}
+
+// WithKeyMap sets the keymap on a tree select field.
+func (t *TreeSelect[T]) WithKeyMap(k *KeyMap) Field {
+	t.keymap = k.TreeSelect
+	t.keymap.Toggle.SetEnabled(t.multiple)
+	return t
+}
+
+// WithAccessible sets the accessible mode of the tree select field.
+func (t *TreeSelect[T]) WithAccessible(accessible bool) Field {
+	t.accessible = accessible
+	return t
+}
+
+// WithWidth sets the width of the tree select field.
+func (t *TreeSelect[T]) WithWidth(width int) Field {
+	t.width = width
+	return t
+}
+
+// WithHeight sets the height of the tree select field.
+func (t *TreeSelect[T]) WithHeight(height int) Field {
+	return t.Height(height)
+}
+
+// WithPosition sets the position of the tree select field.
+func (t *TreeSelect[T]) WithPosition(p FieldPosition) Field {
+	if t.filtering {
+		return t
+	}
+	t.keymap.Prev.SetEnabled(!p.IsFirst())
+	t.keymap.Next.SetEnabled(!p.IsLast())
+	t.keymap.Submit.SetEnabled(p.IsLast())
+	return t
+}
+
+// GetKey returns the key of the field.
+func (t *TreeSelect[T]) GetKey() string { return t.key }
+
+// GetValue returns the value of the field, or its values when multiple
+// choices are allowed.
+func (t *TreeSelect[T]) GetValue() any {
+	if t.multiple {
+		return t.values.Get()
+	}
+	return t.accessor.Get()
+}
+
+// summarize returns the title and the path of the selected node, or the keys
+// of the selected nodes, for the review page.
+func (t *TreeSelect[T]) summarize() (string, string) {
+	if t.multiple {
+		var keys []string
+		walkTree(t.nodes, func(n *TreeNode[T]) bool {
+			if n.state() == treeSelected {
+				keys = append(keys, n.Key)
+			}
+			return true
+		})
+		return t.title, strings.Join(keys, ", ")
+	}
+	if n := t.findNode(t.accessor.Get()); n != nil {
+		return t.title, n.path()
+	}
+	return t.title, fmt.Sprint(t.accessor.Get())
+}
+
+// findNode returns the node matching an answer by value or by key, or nil if
+// there is none.
+func (t *TreeSelect[T]) findNode(value any) *TreeNode[T] {
+	var found *TreeNode[T]
+	v, typed := value.(T)
+	s := answerString(value)
+	walkTree(t.nodes, func(n *TreeNode[T]) bool {
+		if (typed && n.Value == v) || fmt.Sprint(n.Value) == s || n.Key == s {
+			found = n
+		}
+		return found == nil
+	})
+	return found
+}
+
+// HoveredKey returns the key of the node under the cursor, or an empty
+// string if there is none.
+func (t *TreeSelect[T]) HoveredKey() string {
+	if node := t.hovered(); node != nil {
+		return node.Key
+	}
+	return ""
+}
+
+// findAnswer returns the node matching an answer. Lazily loaded children are
+// loaded when no loaded node matches.
+func (t *TreeSelect[T]) findAnswer(value any) (*TreeNode[T], error) {
+	if n := t.findNode(value); n != nil {
+		return n, nil
+	}
+	if err := t.loadAll(); err != nil {
+		return nil, err
+	}
+	if n := t.findNode(value); n != nil {
+		return n, nil
+	}
+	return nil, fmt.Errorf("%v is not one of the options", value)
+}
+
+// setValue sets the value of the field from an answer, matching nodes by
+// value or by key.
+func (t *TreeSelect[T]) setValue(value any) error {
+	if !t.multiple {
+		n, err := t.findAnswer(value)
+		if err != nil {
+			return err
+		}
+		if !n.isLeaf() {
+			return fmt.Errorf("%v groups options, choose one of them", value)
+		}
+		if err := t.validate(n.Value); err != nil {
+			return err
+		}
+		t.filter.SetValue("")
+		t.accessor.Set(n.Value)
+		t.selectValue(n.Value)
+		return nil
+	}
+
+	if v, ok := value.([]T); ok {
+		value = toAnySlice(v)
+	}
+	var nodes []*TreeNode[T]
+	for _, answer := range answerList(value) {
+		n, err := t.findAnswer(answer)
+		if err != nil {
+			return err
+		}
+		nodes = append(nodes, n)
+	}
+	previous := make(map[*TreeNode[T]]bool)
+	walkTree(t.nodes, func(n *TreeNode[T]) bool {
+		previous[n] = n.selected
+		n.selected = false
+		return true
+	})
+	for _, n := range nodes {
+		n.setSelected(true)
+	}
+	if err := t.validateValues(t.selectedValues()); err != nil {
+		for n, selected := range previous {
+			n.selected = selected
+		}
+		return err
+	}
+	t.updateValue()
+	return nil
+}
@@ -0,0 +1,271 @@
+package huh
+
+import (
+	"sort"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// FilterMatch describes how an option matched a filter.
+type FilterMatch struct {
+	// Score ranks the match against other matches, higher scores first.
+	// Options with equal scores keep their original order.
+	Score int
+
+	// Indexes are the positions of the matched runes in the option's key.
+	// They are highlighted with the MatchHighlight style.
+	Indexes []int
+}
+
+// FilterFunc matches an option's key against the filter typed by the user.
+// It reports whether the option matches and, if so, how well.
+//
+// Select and MultiSelect use FilterContains by default. FilterPrefix and
+// FilterFuzzy are also available, and FilterIgnoreDiacritics can wrap any of
+// them.
+type FilterFunc func(filter, key string) (FilterMatch, bool)
+
+// FilterContains matches options whose key contains the filter, ignoring case.
+//
+// All matches score the same, so options keep their original order.
+func FilterContains(filter, key string) (FilterMatch, bool) {
+	f, k := foldCase(filter), foldCase(key)
+	for start := 0; start+len(f) <= len(k); start++ {
+		if hasRunePrefix(k[start:], f) {
+			return FilterMatch{Indexes: runeRange(start, len(f))}, true
+		}
+	}
+	return FilterMatch{}, false
+}
+
+// FilterPrefix matches options whose key starts with the filter, ignoring
+// case.
+func FilterPrefix(filter, key string) (FilterMatch, bool) {
+	f, k := foldCase(filter), foldCase(key)
+	if !hasRunePrefix(k, f) {
+		return FilterMatch{}, false
+	}
+	return FilterMatch{Indexes: runeRange(0, len(f))}, true
+}
+
+// Scores used by FilterFuzzy.
+const (
+	fuzzyMatchScore       = 16
+	fuzzyConsecutiveBonus = 24
+	fuzzyBoundaryBonus    = 20
+	fuzzyMaxLeadPenalty   = 10
+)
+
+// FilterFuzzy matches options whose key contains the characters of the filter
+// in order, though not necessarily next to each other, ignoring case. For
+// example, "nyc" matches "New York City".
+//
+// Matches score higher when the characters are consecutive, start words and
+// appear early in the key.
+func FilterFuzzy(filter, key string) (FilterMatch, bool) {
+	f, k := foldCase(filter), foldCase(key)
+	if len(f) == 0 {
+		return FilterMatch{}, true
+	}
+
+	// Find the end of the first match, then walk back from there to find the
+	// latest start, which gives the tightest window around the match.
+	end, fi := -1, 0
+	for i, r := range k {
+		if r == f[fi] {
+			fi++
+			if fi == len(f) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return FilterMatch{}, false
+	}
+	start, fi := end, len(f)-1
+	for i := end; i >= 0; i-- {
+		if k[i] == f[fi] {
+			fi--
+			if fi < 0 {
+				start = i
+				break
+			}
+		}
+	}
+
+	original := []rune(key)
+	indexes := make([]int, 0, len(f))
+	score := -min(start, fuzzyMaxLeadPenalty) - (end - start + 1 - len(f))
+	fi = 0
+	for i := start; i <= end && fi < len(f); i++ {
+		if k[i] != f[fi] {
+			continue
+		}
+		score += fuzzyMatchScore
+		if fi > 0 && indexes[fi-1] == i-1 {
+			score += fuzzyConsecutiveBonus
+		}
+		if i == 0 || isWordBoundary(original[i-1], original[i]) {
+			score += fuzzyBoundaryBonus
+		}
+		indexes = append(indexes, i)
+		fi++
+	}
+
+	return FilterMatch{Score: score, Indexes: indexes}, true
+}
+
+// FilterIgnoreDiacritics wraps a filter func so that accented Latin letters
+// match their unaccented forms, e.g. "sao" matches "São Paulo".
+func FilterIgnoreDiacritics(fn FilterFunc) FilterFunc {
+	return func(filter, key string) (FilterMatch, bool) {
+		return fn(removeDiacritics(filter), removeDiacritics(key))
+	}
+}
+
+// diacritics maps accented Latin letters to their base letter. Every letter
+// maps to a single rune so that match indexes still line up with the key.
+var diacritics = func() map[rune]rune {
+	m := make(map[rune]rune)
+	for base, variants := range map[rune]string{
+		'a': "àáâãäåāăąǎ", 'A': "ÀÁÂÃÄÅĀĂĄǍ",
+		'c': "çćĉċč", 'C': "ÇĆĈĊČ",
+		'd': "ďđ", 'D': "ĎĐ",
+		'e': "èéêëēĕėęě", 'E': "ÈÉÊËĒĔĖĘĚ",
+		'g': "ĝğġģ", 'G': "ĜĞĠĢ",
+		'h': "ĥħ", 'H': "ĤĦ",
+		'i': "ìíîïĩīĭįı", 'I': "ÌÍÎÏĨĪĬĮİ",
+		'j': "ĵ", 'J': "Ĵ",
+		'k': "ķ", 'K': "Ķ",
+		'l': "ĺļľŀł", 'L': "ĹĻĽĿŁ",
+		'n': "ñńņňŉ", 'N': "ÑŃŅŇ",
+		'o': "òóôõöøōŏőǒ", 'O': "ÒÓÔÕÖØŌŎŐǑ",
+		'r': "ŕŗř", 'R': "ŔŖŘ",
+		's': "śŝşšș", 'S': "ŚŜŞŠȘ",
+		't': "ţťŧț", 'T': "ŢŤŦȚ",
+		'u': "ùúûüũūŭůűųǔ", 'U': "ÙÚÛÜŨŪŬŮŰŲǓ",
+		'w': "ŵ", 'W': "Ŵ",
+		'y': "ýÿŷ", 'Y': "ÝŸŶ",
+		'z': "źżž", 'Z': "ŹŻŽ",
+	} {
+		for _, r := range variants {
+			m[r] = base
+		}
+	}
+	return m
+}()
+
+// removeDiacritics replaces accented letters with their base letter.
+func removeDiacritics(s string) string {
+	runes := []rune(s)
+	for i, r := range runes {
+		if base, ok := diacritics[r]; ok {
+			runes[i] = base
+		}
+	}
+	return string(runes)
+}
+
+// foldCase returns the lowercased runes of s, one for each rune of s.
+func foldCase(s string) []rune {
+	runes := []rune(s)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+func hasRunePrefix(s, prefix []rune) bool {
+	if len(prefix) > len(s) {
+		return false
+	}
+	for i := range prefix {
+		if s[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func runeRange(start, n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = start + i
+	}
+	return indexes
+}
+
+// isWordBoundary reports whether cur starts a new word after prev, either
+// after a separator or at a camelCase hump.
+func isWordBoundary(prev, cur rune) bool {
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
+
+// filterOptions returns the options matching the filter, best matches first.
+// Options under a group header are ranked within their group, and the header
+// is kept above them. Separators are left out.
+func filterOptions[T comparable](options []Option[T], filter string, fn FilterFunc) []Option[T] {
+	if filter == "" {
+		return options
+	}
+
+	type match struct {
+		option Option[T]
+		score  int
+	}
+	var (
+		filtered []Option[T]
+		header   *Option[T]
+		matches  []match
+	)
+	flush := func() {
+		if len(matches) == 0 {
+			return
+		}
+		sort.SliceStable(matches, func(i, j int) bool {
+			return matches[i].score > matches[j].score
+		})
+		if header != nil {
+			filtered = append(filtered, *header)
+		}
+		for _, m := range matches {
+			filtered = append(filtered, m.option)
+		}
+		matches = nil
+	}
+	for i, option := range options {
+		switch option.kind {
+		case optionHeader:
+			flush()
+			header = &options[i]
+		case optionChoice:
+			if m, ok := fn(filter, option.Key); ok {
+				matches = append(matches, match{option, m.Score})
+			}
+		}
+	}
+	flush()
+
+	if filtered == nil {
+		return []Option[T]{}
+	}
+	return filtered
+}
+
+// highlightMatches renders the key with the characters matching the filter
+// highlighted.
+func highlightMatches(key, filter string, fn FilterFunc, style, highlight lipgloss.Style) string {
+	if filter == "" {
+		return style.Render(key)
+	}
+	m, ok := fn(filter, key)
+	if !ok || len(m.Indexes) == 0 {
+		return style.Render(key)
+	}
+	return lipgloss.StyleRunes(key, m.Indexes, highlight.Inherit(style), style)
+}
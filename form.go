@@ -1,7 +1,9 @@
 package huh
 
 import (
+	"bufio"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -12,6 +14,7 @@ import (
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/thedeveloper-sharath/huh/internal/selector"
 )
 
@@ -85,8 +88,37 @@ type Form struct {
 	keymap     *KeyMap
 	timeout    time.Duration
 	teaOptions []tea.ProgramOption
+	input      io.Reader
+	output     io.Writer
 
-	layout Layout
+	layout   Layout
+	theme    *Theme
+	progress Progress
+
+	// help and errors settings, nil unless set, for the groups added later
+	showHelp   *bool
+	showErrors *bool
+
+	// size of the window, to size the groups added while the form runs
+	window tea.WindowSizeMsg
+
+	validate func(results map[string]any) error
+
+	// review page, nil unless enabled with WithReview
+	review *review
+
+	// persistence
+	store          Store
+	persistExclude map[string]bool
+	restored       bool
+	persistErr     error
+
+	// prefilled answers
+	answers        map[string]any
+	fileAnswers    map[string]any
+	answersErr     error
+	envAnswers     bool
+	nonInteractive bool
 }
 
 // NewForm returns a form with the given groups and default themes and
@@ -107,8 +139,11 @@ func NewForm(groups ...*Group) *Form {
 		},
 	}
 
-	// NB: If dynamic forms come into play this will need to be applied when
-	// groups and fields are added.
+	// Groups and fields added later get these in AddGroup and AddField.
+	f.selector.Range(func(_ int, group *Group) bool {
+		group.form = f
+		return true
+	})
 	f.WithKeyMap(f.keymap)
 	f.WithWidth(f.width)
 	f.WithHeight(f.height)
@@ -232,6 +267,7 @@ func (f *Form) WithAccessible(accessible bool) *Form {
 // This allows the form groups and field to show what keybindings are available
 // to the user.
 func (f *Form) WithShowHelp(v bool) *Form {
+	f.showHelp = &v
 	f.selector.Range(func(_ int, group *Group) bool {
 		group.WithShowHelp(v)
 		return true
@@ -244,6 +280,7 @@ func (f *Form) WithShowHelp(v bool) *Form {
 // This allows the form groups and fields to show errors when the Validate
 // function returns an error.
 func (f *Form) WithShowErrors(v bool) *Form {
+	f.showErrors = &v
 	f.selector.Range(func(_ int, group *Group) bool {
 		group.WithShowErrors(v)
 		return true
@@ -260,6 +297,7 @@ func (f *Form) WithTheme(theme *Theme) *Form {
 	if theme == nil {
 		return f
 	}
+	f.theme = theme
 	f.selector.Range(func(_ int, group *Group) bool {
 		group.WithTheme(theme)
 		return true
@@ -315,13 +353,17 @@ func (f *Form) WithHeight(height int) *Form {
 }
 
 // WithOutput sets the io.Writer to output the form.
+//
+// In accessible mode prompts are written to standard output by default.
 func (f *Form) WithOutput(w io.Writer) *Form {
+	f.output = w
 	f.teaOptions = append(f.teaOptions, tea.WithOutput(w))
 	return f
 }
 
 // WithInput sets the io.Reader to the input form.
 func (f *Form) WithInput(r io.Reader) *Form {
+	f.input = r
 	f.teaOptions = append(f.teaOptions, tea.WithInput(r))
 	return f
 }
@@ -346,6 +388,17 @@ func (f *Form) WithLayout(layout Layout) *Form {
 	return f
 }
 
+// Validate sets the validation function of the form.
+//
+// The function runs with the form's results when the user submits the form
+// and is meant for checks spanning several groups, such as a start date being
+// before an end date. Its error is shown in the footer of the last group and
+// keeps the form from being submitted.
+func (f *Form) Validate(validate func(results map[string]any) error) *Form {
+	f.validate = validate
+	return f
+}
+
 // UpdateFieldPositions sets the position on all the fields.
 func (f *Form) UpdateFieldPositions() *Form {
 	firstGroup := 0
@@ -412,6 +465,16 @@ func (f *Form) Errors() []error {
 	return f.selector.Selected().Errors()
 }
 
+// GetFocusedGroup returns the group the user is currently completing.
+func (f *Form) GetFocusedGroup() *Group {
+	return f.selector.Selected()
+}
+
+// GetFocusedField returns the field the user is currently completing.
+func (f *Form) GetFocusedField() Field {
+	return f.selector.Selected().selector.Selected()
+}
+
 // Help returns the current groups' help.
 func (f *Form) Help() help.Model {
 	return f.selector.Selected().help
@@ -439,11 +502,26 @@ func (f *Form) GetString(key string) string {
 
 // GetInt returns a result as a int from the form.
 func (f *Form) GetInt(key string) int {
-	v, ok := f.results[key].(int)
-	if !ok {
-		return 0
+	switch v := f.results[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
 	}
-	return v
+	return 0
+}
+
+// GetFloat returns a result as a float64 from the form.
+func (f *Form) GetFloat(key string) float64 {
+	switch v := f.results[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	}
+	return 0
 }
 
 // GetBool returns a result as a string from the form.
@@ -455,6 +533,52 @@ func (f *Form) GetBool(key string) bool {
 	return v
 }
 
+// Results returns a snapshot of the current values of all the fields with a
+// key, including fields the user hasn't completed or tabbed out of yet.
+func (f *Form) Results() map[string]any {
+	results := make(map[string]any)
+	f.selector.Range(func(_ int, group *Group) bool {
+		group.selector.Range(func(_ int, field Field) bool {
+			if key := field.GetKey(); key != "" {
+				results[key] = field.GetValue()
+			}
+			return true
+		})
+		return true
+	})
+	return results
+}
+
+// MarshalJSON implements json.Marshaler, encoding the form's results as an
+// object by key.
+func (f *Form) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(f.Results())
+	if err != nil {
+		return nil, fmt.Errorf("huh: encoding results: %w", err)
+	}
+	return data, nil
+}
+
+// MarshalYAML implements the Marshaler interface of gopkg.in/yaml.v3,
+// encoding the form's results as a mapping by key.
+func (f *Form) MarshalYAML() (any, error) {
+	return f.Results(), nil
+}
+
+// Unmarshal stores the form's results in the value pointed to by v, usually a
+// struct, following the rules of encoding/json: results are matched to
+// struct fields by their json tag or, case-insensitively, by their name.
+func (f *Form) Unmarshal(v any) error {
+	data, err := f.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("huh: decoding results: %w", err)
+	}
+	return nil
+}
+
 // NextGroup moves the form to the next group.
 func (f *Form) NextGroup() tea.Cmd {
 	_, cmd := f.Update(nextGroup())
@@ -481,6 +605,11 @@ func (f *Form) PrevField() tea.Cmd {
 
 // Init initializes the form.
 func (f *Form) Init() tea.Cmd {
+	// Forms embedded in a program are not run, restore their answers here.
+	if err := f.restore(); err != nil {
+		f.persistErr = err
+	}
+
 	cmds := make([]tea.Cmd, f.selector.Total())
 	f.selector.Range(func(i int, group *Group) bool {
 		if i == 0 {
@@ -506,8 +635,15 @@ func (f *Form) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	group := f.selector.Selected()
 
+	if size, ok := msg.(tea.WindowSizeMsg); ok && f.progress != ProgressNone {
+		// Leave room for the progress above the groups.
+		size.Height -= lipgloss.Height(f.progressView())
+		msg = size
+	}
+
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
+		f.window = msg
 		if f.width > 0 {
 			break
 		}
@@ -531,25 +667,49 @@ func (f *Form) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			f.aborted = true
 			f.quitting = true
 			f.State = StateAborted
+			f.save()
 			return f, f.CancelCmd
 		}
+		if f.Reviewing() {
+			return f.updateReview(msg)
+		}
 
 	case nextFieldMsg:
 		// Form is progressing to the next field, let's save the value of the current field.
 		field := group.selector.Selected()
 		f.results[field.GetKey()] = field.GetValue()
+		f.save()
 
 	case nextGroupMsg:
-		if len(group.Errors()) > 0 {
+		group.err = nil
+		if len(group.Errors()) > 0 || group.runValidation() != nil {
 			return f, nil
 		}
 
 		submit := func() (tea.Model, tea.Cmd) {
-			f.quitting = true
-			f.State = StateCompleted
-			return f, f.SubmitCmd
+			// Forms with a review page are submitted from it instead.
+			if f.review != nil {
+				f.showReview()
+				return f, nil
+			}
+			if err := f.runValidation(); err != nil {
+				group.err = err
+				return f, nil
+			}
+			return f, f.complete()
 		}
 
+		// A group edited from the review page goes back to it, once the
+		// groups shown by the new answers are complete.
+		if f.review != nil && f.review.returning {
+			i := f.newlyShown(f.selector.Index(), f.review.shown)
+			if i < 0 {
+				return submit()
+			}
+			f.selector.SetIndex(i)
+			f.selector.Selected().active = true
+			return f, tea.Batch(f.selector.Selected().Init(), updateFields)
+		}
 		if f.selector.OnLast() {
 			return submit()
 		}
@@ -566,7 +726,9 @@ func (f *Form) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 		f.selector.Selected().active = true
-		return f, f.selector.Selected().Init()
+		// Evaluate the group's dynamic values right away, rather than on the
+		// next message, as the values they depend on may have changed.
+		return f, tea.Batch(f.selector.Selected().Init(), updateFields)
 
 	case prevGroupMsg:
 		if len(group.Errors()) > 0 {
@@ -581,11 +743,14 @@ func (f *Form) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		f.selector.Selected().active = true
-		return f, f.selector.Selected().Init()
+		return f, tea.Batch(f.selector.Selected().Init(), updateFields)
 	}
 
 	m, cmd := group.Update(msg)
-	f.selector.Set(f.selector.Index(), m.(*Group))
+	// Groups may have been added or removed while updating.
+	if f.selector.Selected() == group {
+		f.selector.Set(f.selector.Index(), m.(*Group))
+	}
 
 	// A user input a key, this could hide or show other groups,
 	// let's update all of their positions.
@@ -597,12 +762,65 @@ func (f *Form) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return f, cmd
 }
 
+// isGroupHidden returns whether the group is hidden, either by its hide
+// function or because all of its fields are hidden.
 func (f *Form) isGroupHidden(group *Group) bool {
-	hide := group.hide
-	if hide == nil {
-		return false
+	if group.hide != nil && group.hide() {
+		return true
 	}
-	return hide()
+	hidden := group.selector.Total() > 0
+	group.selector.Range(func(_ int, field Field) bool {
+		hidden = isFieldHidden(field)
+		return hidden
+	})
+	return hidden
+}
+
+// hiddenField is implemented by fields that can be hidden with WithHideFunc
+// or WithShowWhen.
+type hiddenField interface {
+	hidden() bool
+}
+
+// isFieldHidden returns whether the field is hidden. Hidden fields are not
+// displayed, asked or validated.
+func isFieldHidden(field Field) bool {
+	h, ok := field.(hiddenField)
+	return ok && h.hidden()
+}
+
+// fieldHide holds the function hiding a field. Fields embed it to implement
+// hiddenField and back their WithHideFunc and WithShowWhen.
+type fieldHide struct {
+	hide func() bool
+}
+
+// setHideFunc sets the function that checks if the field should be hidden.
+func (h *fieldHide) setHideFunc(hide func() bool) { h.hide = hide }
+
+// setShowWhen sets the function that checks if the field should be shown.
+func (h *fieldHide) setShowWhen(show func() bool) {
+	h.hide = func() bool { return !show() }
+}
+
+// hidden returns whether the field is hidden.
+func (h *fieldHide) hidden() bool { return h.hide != nil && h.hide() }
+
+// complete completes the form, clearing the answers saved while it was in
+// progress.
+func (f *Form) complete() tea.Cmd {
+	f.quitting = true
+	f.State = StateCompleted
+	f.clearSaved()
+	return f.SubmitCmd
+}
+
+// runValidation runs the form's validation function on its results.
+func (f *Form) runValidation() error {
+	if f.validate == nil {
+		return nil
+	}
+	return f.validate(f.Results())
 }
 
 // View renders the form.
@@ -610,8 +828,14 @@ func (f *Form) View() string {
 	if f.quitting {
 		return ""
 	}
-
-	return f.layout.View(f)
+	view := f.layout.View(f)
+	if f.Reviewing() {
+		view = f.reviewView()
+	}
+	if f.progress != ProgressNone {
+		view = f.progressView() + "\n" + view
+	}
+	return view
 }
 
 // Run runs the form.
@@ -628,11 +852,34 @@ func (f *Form) RunWithContext(ctx context.Context) error {
 		return nil
 	}
 
-	if f.accessible {
-		return f.runAccessible()
+	if err := f.restore(); err != nil {
+		return err
 	}
 
-	return f.run(ctx)
+	if f.hasAnswers() {
+		complete, err := f.applyAnswers()
+		if err != nil {
+			return err
+		}
+		if complete {
+			if err := f.validateAnswers(); err != nil {
+				return err
+			}
+			f.complete()
+			return f.persistErr
+		}
+	}
+
+	var err error
+	if f.accessible {
+		err = f.runAccessible(f.accessibleIO())
+	} else {
+		err = f.run(ctx)
+	}
+	if f.persistErr != nil {
+		return errors.Join(err, f.persistErr)
+	}
+	return err
 }
 
 // run runs the form in normal mode.
@@ -658,22 +905,114 @@ func (f *Form) run(ctx context.Context) error {
 	return nil
 }
 
-// runAccessible runs the form in accessible mode.
-func (f *Form) runAccessible() error {
-	// Timeouts are not supported in this mode.
-	if f.timeout > 0 {
-		return ErrTimeoutUnsupported
-	}
-
-	f.selector.Range(func(_ int, group *Group) bool {
+// countQuestions counts the fields that take an answer in the visible groups
+// from the group at index start on.
+func (f *Form) countQuestions(start int) int {
+	var n int
+	f.selector.Range(func(i int, group *Group) bool {
+		if i < start || f.isGroupHidden(group) {
+			return true
+		}
 		group.selector.Range(func(_ int, field Field) bool {
-			field.Init()
-			field.Focus()
-			_ = field.WithAccessible(true).Run()
+			if !field.Skip() {
+				n++
+			}
 			return true
 		})
 		return true
 	})
+	return n
+}
+
+// accessibleIO returns the streams of the form in accessible mode, standard
+// output and input unless set with WithOutput and WithInput.
+func (f *Form) accessibleIO() (io.Writer, io.Reader) {
+	w, r := f.output, f.input
+	if w == nil {
+		w = os.Stdout
+	}
+	if r == nil {
+		r = os.Stdin
+	}
+	return w, r
+}
+
+// runAccessible runs the form in accessible mode, reading answers from r and
+// writing prompts to w.
+//
+// Hidden groups are skipped. Whether a group is hidden is checked when it is
+// reached, so it may depend on the answers to previous groups. Each question
+// is announced with its position in the form, such as "Question 3 of 7".
+func (f *Form) runAccessible(w io.Writer, r io.Reader) error {
+	// Timeouts are not supported in this mode.
+	if f.timeout > 0 {
+		return ErrTimeoutUnsupported
+	}
 
+	// Answers are read buffered for the whole run, rather than a byte at a
+	// time by every field.
+	r = bufio.NewReader(r)
+
+	var (
+		a     = newAccessibleRenderer(w, r)
+		asked int
+		err   error
+	)
+	// Groups and fields are looked up by index as they are reached, so that
+	// those added while the form runs are asked too.
+groups:
+	for i := 0; i < f.selector.Total(); i++ {
+		group := f.selector.Get(i)
+		if f.isGroupHidden(group) {
+			continue
+		}
+		total := asked + f.countQuestions(i)
+		if f.progress != ProgressNone {
+			steps, step := f.steps(i)
+			fmt.Fprintf(w, "Step %d of %d.\n", step+1, len(steps))
+		}
+		if plain(group.title) != "" || plain(group.description) != "" {
+			a.announce(group.title, group.description)
+			fmt.Fprintln(w)
+		}
+		for {
+			question := asked
+			for j := 0; j < group.selector.Total(); j++ {
+				field := group.selector.Get(j)
+				if isFieldHidden(field) {
+					continue
+				}
+				if !field.Skip() {
+					question++
+					fmt.Fprintf(w, "Question %d of %d.\n", question, max(total, question))
+				}
+				field.Init()
+				field.Focus()
+				if err = runAccessible(field, w, r); err != nil {
+					break groups
+				}
+				f.save()
+			}
+			verr := group.runValidation()
+			if verr == nil {
+				asked = question
+				break
+			}
+			a.reject(verr)
+			fmt.Fprintln(w)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("huh: %w", err)
+	}
+
+	if f.review != nil {
+		if err := f.runReviewAccessible(a, w, r); err != nil {
+			return fmt.Errorf("huh: %w", err)
+		}
+	} else if err := f.runValidation(); err != nil {
+		return fmt.Errorf("huh: %w", err)
+	}
+	f.clearSaved()
 	return nil
 }
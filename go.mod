@@ -10,6 +10,7 @@ require (
 	github.com/charmbracelet/x/ansi v0.3.2
 	github.com/charmbracelet/x/exp/strings v0.0.0-20240722160745-212f7b056ed0
 	github.com/mitchellh/hashstructure/v2 v2.0.2
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -33,13 +33,20 @@ type Group struct {
 
 	// errors
 	showErrors bool
+	validate   func() error
+	err        error
 
 	// group options
 	width  int
 	height int
+	theme  *Theme
 	keymap *KeyMap
 	hide   func() bool
 	active bool
+
+	// form the group is part of, to update the positions of the fields
+	// when fields are added or removed
+	form *Form
 }
 
 // NewGroup returns a new group with the given fields.
@@ -87,6 +94,7 @@ func (g *Group) WithShowErrors(show bool) *Group {
 
 // WithTheme sets the theme on a group.
 func (g *Group) WithTheme(t *Theme) *Group {
+	g.theme = t
 	g.help.Styles = t.Help
 	g.selector.Range(func(_ int, field Field) bool {
 		field.WithTheme(t)
@@ -145,18 +153,45 @@ func (g *Group) WithHideFunc(hideFunc func() bool) *Group {
 	return g
 }
 
-// Errors returns the groups' fields' errors.
+// Validate sets the validation function of the group.
+//
+// The function runs when the user moves on from the group and is meant for
+// checks spanning several fields, such as a password confirmation matching
+// the password. Its error is shown in the group's footer and keeps the user
+// on the group.
+func (g *Group) Validate(validate func() error) *Group {
+	g.validate = validate
+	return g
+}
+
+// Errors returns the groups' fields' errors, followed by the group's own
+// validation error.
 func (g *Group) Errors() []error {
 	var errs []error
 	g.selector.Range(func(_ int, field Field) bool {
+		if isFieldHidden(field) {
+			return true
+		}
 		if err := field.Error(); err != nil {
 			errs = append(errs, err)
 		}
 		return true
 	})
+	if g.err != nil {
+		errs = append(errs, g.err)
+	}
 	return errs
 }
 
+// runValidation runs the group's validation function and returns its error.
+func (g *Group) runValidation() error {
+	g.err = nil
+	if g.validate != nil {
+		g.err = g.validate()
+	}
+	return g.err
+}
+
 // updateFieldMsg is a message to update the fields of a group that is currently
 // displayed.
 //
@@ -164,6 +199,11 @@ func (g *Group) Errors() []error {
 // methods to make all fields dynamically update based on user input.
 type updateFieldMsg struct{}
 
+// updateFields is the command to update the fields of the displayed group.
+func updateFields() tea.Msg {
+	return updateFieldMsg{}
+}
+
 // nextFieldMsg is a message to move to the next field,
 //
 // each field controls when to send this message such that it is able to use
@@ -190,11 +230,24 @@ func PrevField() tea.Msg {
 func (g *Group) Init() tea.Cmd {
 	var cmds []tea.Cmd
 
+	g.selector.Range(func(_ int, field Field) bool {
+		if field, ok := field.(valueSyncer); ok {
+			field.syncValue()
+		}
+		return true
+	})
+
+	// Move on from a skipped field, forward if a field after it is shown and
+	// backward otherwise.
 	if g.selector.Selected().Skip() {
-		if g.selector.OnLast() {
-			cmds = append(cmds, g.prevField()...)
-		} else if g.selector.OnFirst() {
+		forward := g.selector.OnFirst()
+		for i := g.selector.Index() + 1; i < g.selector.Total(); i++ {
+			forward = forward || !g.selector.Get(i).Skip()
+		}
+		if forward && !g.selector.OnLast() {
 			cmds = append(cmds, g.nextField()...)
+		} else {
+			cmds = append(cmds, g.prevField()...)
 		}
 		return tea.Batch(cmds...)
 	}
@@ -207,6 +260,25 @@ func (g *Group) Init() tea.Cmd {
 	return tea.Batch(cmds...)
 }
 
+// fieldContainer is implemented by fields made of fields of their own, such as
+// lists, which move through them before the group moves on to another field.
+type fieldContainer interface {
+	// nextField moves to the next field within the field, returning whether
+	// it moved, or kept the focus because of an error.
+	nextField() (bool, tea.Cmd)
+
+	// prevField moves to the previous field within the field, returning
+	// whether it moved, or kept the focus because of an error.
+	prevField() (bool, tea.Cmd)
+}
+
+// valueSyncer is implemented by fields whose value starts out from their
+// options, such as multi-selects with preselected options, which write it to
+// the bound value once the form starts rather than when they are built.
+type valueSyncer interface {
+	syncValue()
+}
+
 // nextField moves to the next field.
 func (g *Group) nextField() []tea.Cmd {
 	blurCmd := g.selector.Selected().Blur()
@@ -241,6 +313,15 @@ func (g *Group) prevField() []tea.Cmd {
 	return []tea.Cmd{blurCmd, focusCmd}
 }
 
+// focusField moves the focus to the field at index i.
+func (g *Group) focusField(i int) tea.Cmd {
+	blurCmd := g.selector.Selected().Blur()
+	g.selector.SetIndex(i)
+	focusCmd := g.selector.Selected().Focus()
+	g.buildView()
+	return tea.Batch(blurCmd, focusCmd)
+}
+
 // Update updates the group.
 func (g *Group) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
@@ -252,26 +333,41 @@ func (g *Group) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			break
 		default:
 			m, cmd := field.Update(msg)
-			g.selector.Set(i, m.(Field))
+			g.replaceField(i, field, m)
 			cmds = append(cmds, cmd)
 		}
 		if g.selector.Index() == i {
 			m, cmd := field.Update(msg)
-			g.selector.Set(i, m.(Field))
+			g.replaceField(i, field, m)
 			cmds = append(cmds, cmd)
 		}
 		m, cmd := field.Update(updateFieldMsg{})
-		g.selector.Set(i, m.(Field))
+		g.replaceField(i, field, m)
 		cmds = append(cmds, cmd)
 		return true
 	})
 
 	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		// Any input may fix the group's validation error.
+		g.err = nil
 	case tea.WindowSizeMsg:
 		g.WithHeight(max(g.height, min(g.fullHeight(), msg.Height-1)))
 	case nextFieldMsg:
+		if c, ok := g.selector.Selected().(fieldContainer); ok {
+			if moved, cmd := c.nextField(); moved {
+				cmds = append(cmds, cmd)
+				break
+			}
+		}
 		cmds = append(cmds, g.nextField()...)
 	case prevFieldMsg:
+		if c, ok := g.selector.Selected().(fieldContainer); ok {
+			if moved, cmd := c.prevField(); moved {
+				cmds = append(cmds, cmd)
+				break
+			}
+		}
 		cmds = append(cmds, g.prevField()...)
 	}
 
@@ -280,11 +376,21 @@ func (g *Group) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return g, tea.Batch(cmds...)
 }
 
+// replaceField replaces the field at index i with the model returned by its
+// Update, unless fields were added or removed meanwhile and it moved.
+func (g *Group) replaceField(i int, field Field, m tea.Model) {
+	if i < g.selector.Total() && g.selector.Get(i) == field {
+		g.selector.Set(i, m.(Field))
+	}
+}
+
 // height returns the full height of the group.
 func (g *Group) fullHeight() int {
-	height := g.selector.Total()
+	var height int
 	g.selector.Range(func(_ int, field Field) bool {
-		height += lipgloss.Height(field.View())
+		if !isFieldHidden(field) {
+			height += lipgloss.Height(field.View()) + 1
+		}
 		return true
 	})
 	return height
@@ -300,14 +406,19 @@ func (g *Group) getContent() (int, string) {
 		g.selector.Selected().WithHeight(g.height - 1)
 		fields.WriteString(g.selector.Selected().View())
 	} else {
+		first := true
 		g.selector.Range(func(i int, field Field) bool {
+			if isFieldHidden(field) {
+				return true
+			}
+			if !first {
+				fields.WriteString(gap)
+			}
+			first = false
 			fields.WriteString(field.View())
 			if i == g.selector.Index() {
 				offset = lipgloss.Height(fields.String()) - lipgloss.Height(field.View())
 			}
-			if i < g.selector.Total()-1 {
-				fields.WriteString(gap)
-			}
 			return true
 		})
 	}
@@ -2,9 +2,14 @@ package huh
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
@@ -13,6 +18,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/x/ansi"
+	"gopkg.in/yaml.v3"
 )
 
 var pretty = lipgloss.NewStyle().
@@ -673,6 +679,213 @@ func TestSelectPageNavigation(t *testing.T) {
 	}
 }
 
+func TestOptionGroups(t *testing.T) {
+	options := func() []Option[string] {
+		return []Option[string]{
+			NewOptionHeader[string]("Europe"),
+			NewOption("Frankfurt", "eu-central-1").Description("eu-central-1"),
+			NewOption("Paris", "eu-west-3").Disabled("at capacity"),
+			NewOptionSeparator[string](),
+			NewOptionHeader[string]("Americas"),
+			NewOption("Virginia", "us-east-1"),
+			NewOption("Oregon", "us-west-2"),
+		}
+	}
+
+	var region string
+	s := NewSelect[string]().Title("Region").Options(options()...).Value(&region)
+	f := NewForm(NewGroup(s))
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	if region != "eu-central-1" {
+		t.Errorf("Expected the cursor to skip the header, got %q", region)
+	}
+	view := ansi.Strip(f.View())
+	for _, want := range []string{"Europe", "Frankfurt eu-central-1", "Paris at capacity", "────"} {
+		if !strings.Contains(view, want) {
+			t.Log(pretty.Render(view))
+			t.Errorf("Expected %q to be displayed", want)
+		}
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if region != "us-east-1" {
+		t.Errorf("Expected the cursor to skip the disabled option, separator and header, got %q", region)
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyUp})
+	f.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if region != "us-west-2" {
+		t.Errorf("Expected the cursor to wrap around to the last option, got %q", region)
+	}
+
+	f.Update(keys('/'))
+	f.Update(keys('o', 'n'))
+	view = ansi.Strip(f.View())
+	if !strings.Contains(view, "Americas") || !strings.Contains(view, "Oregon") || strings.Contains(view, "Europe") || strings.Contains(view, "Virginia") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the filter to keep the header of the matching option")
+	}
+
+	if err := s.setValue("Paris"); err == nil || err.Error() != "Paris is disabled: at capacity" {
+		t.Errorf("Expected the disabled option to be rejected, got %v", err)
+	}
+
+	var out strings.Builder
+	err := s.RunAccessible(&out, strings.NewReader("2\n1\n"))
+	if err != nil || region != "eu-central-1" {
+		t.Errorf("Expected the accessible answer to be eu-central-1, got %q %v", region, err)
+	}
+	for _, want := range []string{"Europe:\n1. Frankfurt - eu-central-1\n2. Paris (disabled: at capacity)\n", "Paris is disabled"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the accessible output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	var regions []string
+	m := NewMultiSelect[string]().Options(options()...).Value(&regions)
+	mf := NewForm(NewGroup(m))
+	mf = batchUpdate(mf, mf.Init()).(*Form)
+	mf.Update(tea.KeyMsg{Type: tea.KeyCtrlA})
+	if want := []string{"eu-central-1", "us-east-1", "us-west-2"}; !reflect.DeepEqual(regions, want) {
+		t.Errorf("Expected selecting all to leave the disabled option out, got %v", regions)
+	}
+}
+
+func TestOptionPreview(t *testing.T) {
+	var calls []string
+	preview := func(lang string) string {
+		calls = append(calls, lang)
+		return "Preview of " + lang
+	}
+
+	s := NewSelect[string]().
+		Title("Language").
+		Options(NewOptions("go", "rust", "zig")...).
+		Preview(preview)
+	s.WithTheme(ThemeCharm())
+	s.WithKeyMap(NewDefaultKeyMap())
+	s.WithWidth(80)
+	s.Focus()
+
+	_, cmd := s.Update(updateFieldMsg{})
+	if cmd == nil {
+		t.Fatal("Expected the preview to be computed in a command")
+	}
+	batchUpdate(s, cmd)
+	view := ansi.Strip(s.View())
+	if !strings.Contains(view, "Preview of go") || !strings.Contains(view, "│") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the preview to be shown in a pane next to the options")
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, cmd = s.Update(updateFieldMsg{})
+	batchUpdate(s, cmd)
+	if view := ansi.Strip(s.View()); !strings.Contains(view, "Preview of rust") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the preview to follow the cursor")
+	}
+
+	s.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if _, cmd = s.Update(updateFieldMsg{}); cmd != nil {
+		t.Error("Expected the cached preview to be shown without a command")
+	}
+	if !reflect.DeepEqual(calls, []string{"go", "rust"}) {
+		t.Errorf("Expected each preview to be computed once, got %v", calls)
+	}
+
+	s.WithWidth(30)
+	view = ansi.Strip(s.View())
+	if !strings.Contains(view, "Preview of go") || strings.Contains(view, "│") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the preview to fall back to an inline view in a narrow field")
+	}
+
+	s.PreviewPosition(PreviewBottom).PreviewSize(3)
+	view = ansi.Strip(s.View())
+	if strings.Index(view, "Preview of go") < strings.Index(view, "zig") || !strings.Contains(view, "─") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the preview to be shown in a pane below the options")
+	}
+
+	m := NewMultiSelect[string]().
+		Options(NewOptions("go", "rust")...).
+		Preview(preview).
+		PreviewPosition(PreviewBottom)
+	m.WithTheme(ThemeCharm())
+	m.WithWidth(40)
+	_, cmd = m.Update(updateFieldMsg{})
+	batchUpdate(m, cmd)
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "Preview of go") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the multi-select field to show the preview")
+	}
+}
+
+func TestMarkdown(t *testing.T) {
+	md := "# Release\n\n" +
+		"Adds *emphasis*, `code` and [docs](https://charm.sh/docs), keeps snake_case and \\*stars\\*.\n\n" +
+		"- First\n  - Nested\n- Second\n\n" +
+		"1. One\n1. Two\n\n" +
+		"> Quoted\n\n" +
+		"```\nfunc main() {}\n```\n\n" +
+		"| Name | Size |\n|------|-----:|\n| a | 1 |\n"
+
+	note := NewNote().Title("Notes").Description(md)
+	f := NewForm(NewGroup(note)).WithWidth(40)
+	f = batchUpdate(f, f.Init()).(*Form)
+	view := ansi.Strip(note.View())
+	for _, want := range []string{
+		"Release",
+		"Adds emphasis,  code  and docs",
+		"(https://charm.sh/docs), keeps",
+		"snake_case and *stars*.",
+		"• First",
+		"  • Nested",
+		"1. One",
+		"2. Two",
+		"│ Quoted",
+		"  func main() {}",
+		"Name │ Size",
+		"a    │    1",
+	} {
+		if !strings.Contains(view, want) {
+			t.Log(view)
+			t.Errorf("Expected %q to be displayed", want)
+		}
+	}
+	if strings.Contains(view, "#") || strings.Contains(view, "```") {
+		t.Log(view)
+		t.Error("Expected the markup to be rendered")
+	}
+
+	var out strings.Builder
+	if err := note.RunAccessible(&out, nil); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Release\n", "- First\n", "  - Nested\n", "> Quoted\n", "Name | Size\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the accessible output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+
+	theme := ThemeCharm()
+	theme.MarkdownDescriptions = true
+	input := NewInput().Title("Name").Description("Use **your** name.")
+	f = NewForm(NewGroup(input, NewInput().Description("Keep **this**."))).WithTheme(theme)
+	f = batchUpdate(f, f.Init()).(*Form)
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "Use your name.") || !strings.Contains(view, "Keep this.") {
+		t.Log(view)
+		t.Error("Expected the descriptions to be rendered as Markdown")
+	}
+	f = NewForm(NewGroup(NewInput().Description("Keep **this**.")))
+	f = batchUpdate(f, f.Init()).(*Form)
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "Keep **this**.") {
+		t.Log(view)
+		t.Error("Expected descriptions not to be rendered as Markdown by default")
+	}
+}
+
 func TestFile(t *testing.T) {
 	field := NewFilePicker().Title("Which file?")
 	cmd := field.Init()
@@ -773,6 +986,31 @@ func TestHideGroupLastAndFirstGroupsNotHidden(t *testing.T) {
 	}
 }
 
+func TestLayoutPages(t *testing.T) {
+	groups := func() []*Group {
+		var groups []*Group
+		for _, title := range []string{"One", "Two", "Three", "Four", "Five"} {
+			groups = append(groups, NewGroup(NewNote().Title(title)))
+		}
+		return groups
+	}
+	for name, layout := range map[string]Layout{
+		"columns": LayoutColumns(2),
+		"grid":    LayoutGrid(1, 2),
+	} {
+		f := NewForm(groups()...).WithLayout(layout).WithWidth(60)
+		f = batchUpdate(f, f.Init()).(*Form)
+		f.Update(nextGroup())
+		f.Update(nextGroup())
+
+		view := ansi.Strip(f.View())
+		if !strings.Contains(view, "Three") || !strings.Contains(view, "Four") || strings.Contains(view, "One") {
+			t.Log(pretty.Render(view))
+			t.Errorf("Expected the %s layout to show the second page of groups", name)
+		}
+	}
+}
+
 func TestPrevGroup(t *testing.T) {
 	f := NewForm(
 		NewGroup(NewNote().Description("Bar")),
@@ -818,6 +1056,26 @@ func TestNote(t *testing.T) {
 		t.Log(view)
 		t.Error("Expected field to contain help.")
 	}
+
+	// The next button is set apart from the description when there is one.
+	for _, note := range []*Note{
+		NewNote().Title("Taco").Description("Order?").Next(true),
+		NewNote().Title("Taco").DescriptionFunc(func() string { return "Order?" }, nil).Next(true),
+		NewNote().Title("Taco").Description("  ").Next(true),
+	} {
+		f := NewForm(NewGroup(note))
+		f = batchUpdate(f, f.Init()).(*Form)
+		view := ansi.Strip(note.View())
+		lines := strings.Split(view, "\n")
+		var next int
+		for next < len(lines) && !strings.Contains(lines[next], "Next") {
+			next++
+		}
+		if next < 2 || strings.TrimSpace(lines[next-1]) != "" || strings.TrimSpace(lines[next-2]) == "" {
+			t.Log(view)
+			t.Errorf("Expected a single blank line before the next button, for description %q", note.description.val)
+		}
+	}
 }
 
 func TestDynamicHelp(t *testing.T) {
@@ -890,53 +1148,1906 @@ func TestSkip(t *testing.T) {
 	}
 }
 
-func TestTimeout(t *testing.T) {
-	// This test requires a real program, so make sure it doesn't interfere with our test runner.
-	f := formProgram()
+func TestHideField(t *testing.T) {
+	var company bool
+	f := NewForm(
+		NewGroup(
+			NewInput().Title("Name"),
+			NewInput().Title("Company").
+				Validate(func(s string) error { return errors.New("required") }).
+				WithShowWhen(func() bool { return company }),
+			NewInput().Title("Email"),
+		),
+		NewGroup(
+			NewNote().Title("Hidden").WithHideFunc(func() bool { return true }),
+		),
+	).WithWidth(25)
 
-	// Test that the form times out after 1ms and returns a timeout error.
-	err := f.WithTimeout(1 * time.Millisecond).Run()
-	if err == nil || !errors.Is(err, ErrTimeout) {
-		t.Errorf("expected timeout error, got %v", err)
+	f = batchUpdate(f, f.Init()).(*Form)
+	if view := ansi.Strip(f.View()); strings.Contains(view, "Company") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected hidden field not to be displayed")
+	}
+
+	f.Update(NextField())
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "┃ Email") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected hidden field to be skipped")
+	}
+	if errs := f.Errors(); len(errs) > 0 {
+		t.Errorf("Expected hidden field not to be validated, got %v", errs)
+	}
+
+	company = true
+	f.Update(PrevField())
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "┃ Company") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected shown field to be focused")
+	}
+
+	company = false
+	f.Update(PrevField())
+	f.Update(NextField())
+	batchUpdate(f.Update(NextField()))
+	if f.State != StateCompleted {
+		t.Error("Expected the group with only hidden fields to be hidden")
+	}
+
+	// A group starting on a hidden field moves to the closest shown one.
+	hidden := func() bool { return true }
+	group := NewGroup(
+		NewInput().Title("A"),
+		NewInput().Title("B").WithHideFunc(hidden),
+		NewInput().Title("C"),
+		NewInput().Title("D").WithHideFunc(hidden),
+		NewInput().Title("E").WithHideFunc(hidden),
+	)
+	group.active = true
+	for _, tc := range []struct{ from, want int }{{1, 2}, {3, 2}, {4, 2}} {
+		group.selector.SetIndex(tc.from)
+		group.Init()
+		if group.selector.Index() != tc.want {
+			t.Errorf("Expected a group starting on field %d to move to field %d, got %d", tc.from, tc.want, group.selector.Index())
+		}
+	}
+
+	var out strings.Builder
+	err := NewForm(NewGroup(
+		NewInput().Title("Name"),
+		NewInput().Title("Company").WithHideFunc(func() bool { return true }),
+	)).WithAccessible(true).WithInput(strings.NewReader("Ada\n")).WithOutput(&out).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output := out.String(); strings.Contains(output, "Company") || !strings.Contains(output, "Question 1 of 1.") {
+		t.Log(output)
+		t.Error("Expected hidden field not to be asked in accessible mode")
+	}
+
+	def := `{"groups": [{"fields": [
+		{"type": "confirm", "key": "company", "title": "Company?"},
+		{"type": "input", "key": "vat", "title": "VAT", "hide": {"key": "company", "equals": false}}
+	]}]}`
+	form, err := NewFormFromJSON(strings.NewReader(def))
+	if err != nil {
+		t.Fatal(err)
+	}
+	form = batchUpdate(form, form.Init()).(*Form)
+	if view := ansi.Strip(form.View()); strings.Contains(view, "VAT") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected field hidden by its definition not to be displayed")
+	}
+	def = strings.Replace(def, `"key": "company", "equals"`, `"key": "nope", "equals"`, 1)
+	if _, err := NewFormFromJSON(strings.NewReader(def)); err == nil {
+		t.Error("Expected a hide condition on an unknown key to be an error")
 	}
 }
 
-func TestAbort(t *testing.T) {
-	// This test requires a real program, so make sure it doesn't interfere with our test runner.
-	f := formProgram()
+func TestDynamicGroups(t *testing.T) {
+	var (
+		form   *Form
+		hosts  []string
+		server func() *Group
+	)
+	server = func() *Group {
+		var (
+			host  string
+			more  bool
+			group *Group
+		)
+		group = NewGroup(
+			NewInput().Title("Host").Value(&host),
+			NewConfirm().Title("Another server?").Value(&more),
+		).Validate(func() error {
+			hosts = append(hosts, host)
+			if more {
+				form.InsertGroupAfter(group, server())
+			}
+			return nil
+		})
+		return group
+	}
+	form = NewForm(
+		server(),
+		NewGroup(NewNote().Title("Done")),
+	).WithTheme(ThemeBase()).WithWidth(40)
 
-	// Test that the form aborts without throwing a timeout error when explicitly told to abort.
-	ctx, cancel := context.WithCancel(context.Background())
-	// Since the context is cancelled, the program should exit immediately.
-	cancel()
-	// Tell the form to abort.
-	f.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
-	// Run the program.
-	err := f.RunWithContext(ctx)
-	if err == nil || !errors.Is(err, ErrUserAborted) {
-		t.Errorf("expected user aborted error, got %v", err)
+	form = batchUpdate(form, form.Init()).(*Form)
+	form.Update(keys('a'))
+	form.Update(NextField())
+	batchUpdate(form.Update(keys('y')))
+
+	if total := form.selector.Total(); total != 3 {
+		t.Fatalf("Expected a group to be added, got %d groups", total)
+	}
+	added := form.selector.Get(1)
+	if form.GetFocusedGroup() != added {
+		t.Error("Expected the added group to be focused")
+	}
+	if added.theme != form.theme || added.width != 40 {
+		t.Error("Expected the added group to get the form's theme and width")
+	}
+
+	form.Update(keys('b'))
+	form.Update(NextField())
+	batchUpdate(form.Update(keys('n')))
+	if !reflect.DeepEqual(hosts, []string{"a", "b"}) {
+		t.Errorf("Expected hosts a and b, got %v", hosts)
+	}
+	if view := ansi.Strip(form.View()); !strings.Contains(view, "Done") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the last group to be focused")
+	}
+
+	done := form.GetFocusedGroup()
+	form.RemoveGroup(added)
+	if form.selector.Total() != 2 || form.GetFocusedGroup() != done {
+		t.Error("Expected the focused group to stay focused when removing another")
+	}
+	form.RemoveGroup(done)
+	if form.GetFocusedGroup() != form.selector.Get(0) || !form.selector.Get(0).active {
+		t.Error("Expected the focus to move to the remaining group")
+	}
+	form.RemoveGroup(form.selector.Get(0))
+	if form.selector.Total() != 1 {
+		t.Error("Expected the last group not to be removed")
+	}
+
+	second := NewInput().Title("Second")
+	group := form.selector.Get(0).AddField(second)
+	if confirm := group.selector.Get(1).(*Confirm); !confirm.keymap.Next.Enabled() || confirm.keymap.Submit.Enabled() {
+		t.Error("Expected the previous last field to move on to the added field")
+	}
+	if view := ansi.Strip(form.View()); !strings.Contains(view, "Second") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the added field to be displayed")
+	}
+	form.Update(PrevField())
+	form.Update(NextField())
+	form.Update(NextField())
+	if form.GetFocusedField() != second {
+		t.Error("Expected the added field to be focused")
+	}
+	group.RemoveField(second)
+	if view := ansi.Strip(form.View()); strings.Contains(view, "Second") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the removed field not to be displayed")
+	}
+	if form.GetFocusedField() == second || group.selector.Total() != 2 {
+		t.Error("Expected the removed field to lose the focus")
+	}
+
+	intro := NewGroup(NewNote().Title("Intro"))
+	next := NewForm(intro, NewGroup(NewInput().Title("Name")))
+	next = batchUpdate(next, next.Init()).(*Form)
+	if cmd := next.RemoveGroup(intro); cmd == nil {
+		t.Error("Expected the command initializing the group moved to")
+	}
+
+	tall := NewSelect[int]().Options(NewOptions(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12)...)
+	short := NewGroup(NewInput()).WithHeight(6)
+	NewForm(short).WithAccessible(true)
+	short.AddField(tall)
+	if tall.height != 6 || !tall.accessible {
+		t.Errorf("Expected the added field to get the group's height and the form's accessible mode, got %d %v", tall.height, tall.accessible)
+	}
+
+	quiet := NewGroup(NewInput())
+	NewForm(NewGroup(NewNote())).WithShowHelp(false).WithShowErrors(false).AddGroup(quiet)
+	if quiet.showHelp || quiet.showErrors {
+		t.Error("Expected the added group to get the form's help and errors settings")
+	}
+
+	hosts = nil
+	form = NewForm(server())
+	err := form.WithAccessible(true).
+		WithInput(strings.NewReader("a\ny\nb\nn\n")).
+		WithOutput(io.Discard).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hosts, []string{"a", "b"}) {
+		t.Errorf("Expected groups added in accessible mode to be asked, got %v", hosts)
 	}
 }
 
-// formProgram returns a new Form with a nil input and output, so it can be used as a test program.
-func formProgram() *Form {
-	return NewForm(NewGroup(NewInput().Title("Foo"))).
-		WithInput(nil).WithOutput(io.Discard).
-		WithAccessible(false)
+func TestList(t *testing.T) {
+	type env struct{ Name, Value string }
+	envs := []env{{"A", "1"}}
+	list := NewList[env]().
+		Title("Environment").
+		Template(func(e *env) []Field {
+			return []Field{
+				NewInput().Title("Name").Value(&e.Name),
+				NewInput().Title("Value").Value(&e.Value),
+			}
+		}).
+		Validate(func(e env) error {
+			if e.Name == "" {
+				return errors.New("name is required")
+			}
+			return nil
+		}).
+		Value(&envs)
+	f := NewForm(NewGroup(list, NewInput().Title("After"))).WithWidth(40)
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "1.") || !strings.Contains(view, "Name") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the entries to be displayed")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}, Alt: true})
+	if len(envs) != 2 || list.cursor != 1 {
+		t.Fatalf("Expected an entry to be added and focused, got %v", envs)
+	}
+
+	// The new entry has no name, so it can't be left.
+	f.Update(NextField())
+	f.Update(NextField())
+	if f.GetFocusedField() != list {
+		t.Fatal("Expected an invalid entry to keep the focus")
+	}
+	if errs := f.Errors(); len(errs) != 1 || errs[0].Error() != "entry 2: name is required" {
+		t.Errorf("Expected the entry's validation error, got %v", errs)
+	}
+
+	f.Update(PrevField())
+	f.Update(keys('B'))
+	f.Update(NextField())
+	f.Update(NextField())
+	if f.GetFocusedField() == list {
+		t.Fatal("Expected the list to be left after its last entry")
+	}
+	if !reflect.DeepEqual(envs, []env{{"A", "1"}, {"B", ""}}) {
+		t.Errorf("Unexpected entries %v", envs)
+	}
+
+	f.Update(PrevField())
+	f.Update(tea.KeyMsg{Type: tea.KeyUp, Alt: true})
+	if !reflect.DeepEqual(envs, []env{{"B", ""}, {"A", "1"}}) {
+		t.Errorf("Expected the entry to move up, got %v", envs)
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}, Alt: true})
+	if !reflect.DeepEqual(envs, []env{{"A", "1"}}) {
+		t.Errorf("Expected the entry to be removed, got %v", envs)
+	}
+
+	envs = nil
+	list = NewList[env]().
+		Title("Environment").
+		Template(func(e *env) []Field {
+			return []Field{NewInput().Title("Name").Value(&e.Name)}
+		}).
+		Value(&envs)
+	var out strings.Builder
+	err := list.RunAccessible(&out, strings.NewReader("y\nA\ny\nB\nn\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(envs, []env{{"A", ""}, {"B", ""}}) {
+		t.Errorf("Unexpected entries in accessible mode %v", envs)
+	}
+	for _, want := range []string{"Add an entry? [n]: ", "Entry 2.\n", "Add another? [n]: ", "Answer: A; B\n"} {
+		if !strings.Contains(out.String(), want) {
+			t.Log(out.String())
+			t.Errorf("Expected output to contain %q", want)
+		}
+	}
 }
 
-func batchUpdate(m tea.Model, cmd tea.Cmd) tea.Model {
-	if cmd == nil {
-		return m
+// initField is a field reporting when it is initialized.
+type initField struct {
+	Field
+}
+
+type fieldInitMsg struct{}
+
+func (f *initField) Init() tea.Cmd {
+	return func() tea.Msg { return fieldInitMsg{} }
+}
+
+func TestListAddInitializesEntry(t *testing.T) {
+	list := NewList[string]().Template(func(s *string) []Field {
+		return []Field{&initField{Field: NewInput().Value(s)}}
+	})
+	list.WithKeyMap(NewDefaultKeyMap())
+	list.Focus()
+
+	_, cmd := list.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}, Alt: true})
+	if len(list.rows) != 1 {
+		t.Fatal("Expected an entry to be added")
+	}
+	var inits int
+	var collect func(tea.Cmd)
+	collect = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, cmd := range msg {
+				collect(cmd)
+			}
+		case fieldInitMsg:
+			inits++
+		}
 	}
-	msg := cmd()
-	m, cmd = m.Update(msg)
-	if cmd == nil {
-		return m
+	collect(cmd)
+	if inits != 1 {
+		t.Errorf("Expected the fields of the new entry to be initialized, got %d", inits)
+	}
+}
+
+func TestKeyValue(t *testing.T) {
+	labels := map[string]string{"app": "web", "env": "prod"}
+	kv := NewKeyValue().
+		Title("Labels").
+		KeySuggestions([]string{"team", "tier"}).
+		ValidateValue(func(s string) error {
+			if strings.Contains(s, " ") {
+				return errors.New("no spaces")
+			}
+			return nil
+		}).
+		Value(&labels)
+	f := NewForm(NewGroup(kv, NewInput().Title("After"))).WithWidth(50)
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "Key> app") || !strings.Contains(view, "Value> prod") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the keys and values to be displayed")
+	}
+	if key := kv.list.rows[0].fields.Get(0).(*Input); !key.textinput.ShowSuggestions {
+		t.Error("Expected the key inputs to suggest keys")
+	}
+
+	for _, binding := range kv.KeyBinds() {
+		if binding.Enabled() && strings.HasPrefix(binding.Help().Desc, "move") {
+			t.Errorf("Expected entries not to be movable, got %q", binding.Help().Key)
+		}
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'n'}, Alt: true})
+	f.Update(NextField())
+	f.Update(NextField())
+	if errs := f.Errors(); len(errs) != 1 || errs[0].Error() != "entry 2: key is required" {
+		t.Errorf("Expected a missing key error, got %v", errs)
+	}
+
+	f.Update(PrevField())
+	f.Update(keys('a', 'p', 'p'))
+	f.Update(NextField())
+	f.Update(NextField())
+	if errs := f.Errors(); len(errs) != 1 || errs[0].Error() != `entry 2: duplicate key "app"` {
+		t.Errorf("Expected a duplicate key error, got %v", errs)
+	}
+
+	f.Update(PrevField())
+	f.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	f.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	f.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	f.Update(keys('t', 'i', 'e', 'r'))
+	f.Update(NextField())
+	f.Update(keys('f', 'e'))
+	// Move through the last row, env.
+	for i := 0; i < 3; i++ {
+		f.Update(NextField())
+	}
+	if f.GetFocusedField() == kv {
+		t.Fatalf("Expected the field to be left, got errors %v", f.Errors())
+	}
+	if want := map[string]string{"app": "web", "env": "prod", "tier": "fe"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("Expected labels %v, got %v", want, labels)
+	}
+
+	f.Update(PrevField())
+	f.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}, Alt: true})
+	if want := map[string]string{"app": "web", "tier": "fe"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("Expected labels %v after removing a row, got %v", want, labels)
+	}
+
+	if err := kv.setValue(map[string]any{"app": "a b"}); err == nil {
+		t.Error("Expected an invalid value to be rejected")
+	}
+}
+
+func TestKeyValueAccessible(t *testing.T) {
+	labels := map[string]string{"a": "1"}
+	kv := NewKeyValue().Title("Labels").Value(&labels)
+
+	var out strings.Builder
+	if err := kv.RunAccessible(&out, strings.NewReader("\n\ny\na\n2\nb\n2\nn\n")); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), `duplicate key "a"`) {
+		t.Errorf("Expected the duplicate key to be rejected, got:\n%s", out.String())
+	}
+	if want := map[string]string{"a": "1", "b": "2"}; !reflect.DeepEqual(labels, want) {
+		t.Errorf("Expected labels %v, got %v", want, labels)
+	}
+}
+
+func TestOrderedList(t *testing.T) {
+	var targets []string
+	list := NewOrderedList[string]().
+		Title("Deployment order").
+		Options(NewOptions("canary", "eu", "us", "asia")...).
+		Pinned("canary").
+		Limit(3).
+		Value(&targets)
+	f := NewForm(NewGroup(list))
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	if want := []string{"canary", "eu", "us"}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("Expected the first options to be ranked, got %v", targets)
+	}
+	view := ansi.Strip(f.View())
+	for _, want := range []string{"1. canary (pinned)", "3. us", "────", "asia"} {
+		if !strings.Contains(view, want) {
+			t.Log(pretty.Render(view))
+			t.Errorf("Expected %q to be displayed", want)
+		}
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	f.Update(tea.KeyMsg{Type: tea.KeyShiftUp})
+	if want := []string{"canary", "eu", "us"}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("Expected the pinned option not to move, got %v", targets)
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	f.Update(keys('J'))
+	if want := []string{"canary", "us", "asia"}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("Expected eu to move out of the ranking, got %v", targets)
+	}
+
+	if err := list.setValue([]string{"asia", "eu"}); err != nil || !reflect.DeepEqual(targets, []string{"canary", "asia", "eu"}) {
+		t.Errorf("Expected the answer to rank asia and eu, got %v %v", targets, err)
+	}
+	if err := list.setValue("eu, eu"); err == nil {
+		t.Error("Expected an option ranked twice to be rejected")
+	}
+
+	var out strings.Builder
+	if err := list.RunAccessible(&out, strings.NewReader("4\n\n1\n2\n")); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"canary", "us", "eu"}; !reflect.DeepEqual(targets, want) {
+		t.Errorf("Expected the accessible answers to rank %v, got %v", want, targets)
+	}
+	for _, want := range []string{"Only the first 3 options are ranked.", "Position of asia [2]:", "position 1 is pinned", "4. asia (not ranked)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("Expected the accessible output to contain %q, got:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestOrderedListDuplicateValues(t *testing.T) {
+	var values []string
+	list := NewOrderedList[string]().
+		Options(NewOption("a", "x"), NewOption("b", "x"), NewOption("c", "y")).
+		Pinned("y").
+		Value(&values).
+		WithKeyMap(NewDefaultKeyMap()).(*OrderedList[string])
+	if want := []string{"x", "x", "y"}; !reflect.DeepEqual(values, want) {
+		t.Errorf("Expected value %v, got %v", want, values)
+	}
+
+	list.Update(tea.KeyMsg{Type: tea.KeyShiftDown})
+	if key := list.HoveredKey(); key != "a" || list.options[0].Key != "b" {
+		t.Errorf("Expected a to be moved below b, got %v", list.options)
+	}
+	if err := list.setValue([]string{"x"}); err != nil {
+		t.Fatal(err)
+	}
+	if list.options[0].Key != "b" || list.options[1].Key != "a" {
+		t.Errorf("Expected the options sharing a value to keep their order, got %v", list.options)
+	}
+	list.Accessor(NewPointerAccessor(&[]string{"y", "x", "x", "x"}))
+
+	var out strings.Builder
+	if err := list.RunAccessible(&out, strings.NewReader("2\n\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, summary := list.summarize(); summary != "a, b, c" {
+		t.Errorf("Expected a to be moved to the top, got %q", summary)
+	}
+}
+
+func TestTreeSelect(t *testing.T) {
+	var service string
+	tree := NewTreeSelect[string]().
+		Title("Service").
+		Nodes(
+			NewTreeNode("Engineering", "eng",
+				NewTreeNode("Platform", "platform",
+					NewTreeNode("api", "api"),
+					NewTreeNode("gateway", "gateway"),
+				),
+			),
+			NewTreeNode("Sales", "sales").ChildrenFunc(func() ([]*TreeNode[string], error) {
+				return []*TreeNode[string]{NewTreeNode("crm", "crm")}, nil
+			}),
+		).
+		Value(&service)
+	f := NewForm(NewGroup(tree))
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "▸ Engineering") || strings.Contains(view, "Platform") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the tree to be collapsed")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyRight})
+	f.Update(tea.KeyMsg{Type: tea.KeyRight})
+	f.Update(tea.KeyMsg{Type: tea.KeyRight})
+	f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if service != "api" {
+		t.Errorf("Expected the value to follow the cursor into the tree, got %q", service)
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	if tree.hovered().Value != "platform" || service != "api" {
+		t.Errorf("Expected collapsing a leaf to move to its parent, keeping the value, got %q", service)
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if f.GetFocusedField() != tree || tree.hovered().Value != "api" {
+		t.Error("Expected choosing a group to move into it rather than submit it")
+	}
+	if err := tree.setValue("platform"); err == nil {
+		t.Error("Expected an answer choosing a group to be an error")
+	}
+
+	f.Update(keys('G'))
+	_, cmd := tree.Update(tea.KeyMsg{Type: tea.KeyRight})
+	if view := ansi.Strip(tree.View()); !strings.Contains(view, "Sales Loading...") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the children to be loading")
+	}
+	f.Update(cmd())
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "crm") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the loaded children to be displayed")
+	}
+
+	f.Update(keys('/'))
+	f.Update(keys('g', 'a', 't'))
+	view := ansi.Strip(f.View())
+	if !strings.Contains(view, "Engineering") || !strings.Contains(view, "gateway") || strings.Contains(view, "api") || strings.Contains(view, "Sales") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the filter to show matches with their ancestors")
+	}
+	if service != "gateway" {
+		t.Errorf("Expected the filtered node to be selected, got %q", service)
+	}
+
+	var teams []string
+	multi := NewTreeSelect[string]().
+		Nodes(NewTreeNode("Engineering", "eng", NewTreeNode("api", "api"), NewTreeNode("web", "web")).Expanded(true)).
+		Values(&teams)
+	m := NewForm(NewGroup(multi))
+	m = batchUpdate(m, m.Init()).(*Form)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(keys('x'))
+	if !reflect.DeepEqual(teams, []string{"api"}) {
+		t.Errorf("Expected api to be selected, got %v", teams)
+	}
+	if view := ansi.Strip(m.View()); !strings.Contains(view, "- Engineering") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the parent to be partly selected")
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyUp})
+	m.Update(keys('x'))
+	if want := []string{"eng", "api", "web"}; !reflect.DeepEqual(teams, want) {
+		t.Errorf("Expected selecting the parent to select %v, got %v", want, teams)
+	}
+
+	if err := multi.setValue([]any{"web"}); err != nil || !reflect.DeepEqual(teams, []string{"web"}) {
+		t.Errorf("Expected the answer to select web, got %v %v", teams, err)
+	}
+
+	var team string
+	lazy := NewTreeSelect[string]().
+		Nodes(NewTreeNode("Org", "org").ChildrenFunc(func() ([]*TreeNode[string], error) {
+			return []*TreeNode[string]{NewTreeNode("Team", "team")}, nil
+		})).
+		Value(&team)
+	if err := lazy.setValue("team"); err != nil || team != "team" {
+		t.Errorf("Expected the answer to select a lazily loaded node, got %q %v", team, err)
+	}
+	failing := NewTreeSelect[string]().
+		Nodes(NewTreeNode("Org", "org").ChildrenFunc(func() ([]*TreeNode[string], error) {
+			return nil, errors.New("offline")
+		}))
+	if err := failing.setValue("team"); err == nil || err.Error() != "loading Org: offline" {
+		t.Errorf("Expected the loading error, got %v", err)
+	}
+
+	// Filtering loads the lazy nodes, level by level.
+	filtered := NewTreeSelect[string]().
+		Nodes(NewTreeNode("Org", "org").ChildrenFunc(func() ([]*TreeNode[string], error) {
+			return []*TreeNode[string]{NewTreeNode("Team", "team").ChildrenFunc(func() ([]*TreeNode[string], error) {
+				return []*TreeNode[string]{NewTreeNode("billing", "billing")}, nil
+			})}, nil
+		}))
+	filtered.WithKeyMap(NewDefaultKeyMap())
+	filtered.Focus()
+	var pump func(tea.Cmd)
+	pump = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, cmd := range msg {
+				pump(cmd)
+			}
+		case treeChildrenMsg[string]:
+			_, cmd := filtered.Update(msg)
+			pump(cmd)
+		}
+	}
+	_, cmd = filtered.Update(keys('/'))
+	pump(cmd)
+	filtered.Update(keys('b', 'i', 'l'))
+	if view := ansi.Strip(filtered.View()); !strings.Contains(view, "billing") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the filter to match lazily loaded nodes")
+	}
+
+	var out strings.Builder
+	tree = NewTreeSelect[string]().
+		Nodes(NewTreeNode("Platform", "platform", NewTreeNode("api", "api"))).
+		Value(&service)
+	if err := tree.RunAccessible(&out, strings.NewReader("1\n")); err != nil {
+		t.Fatal(err)
+	}
+	if service != "api" || strings.Contains(out.String(), "2.") {
+		t.Log(out.String())
+		t.Errorf("Expected only the leaves to be choices, got %q", service)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	// This test requires a real program, so make sure it doesn't interfere with our test runner.
+	f := formProgram()
+
+	// Test that the form times out after 1ms and returns a timeout error.
+	err := f.WithTimeout(1 * time.Millisecond).Run()
+	if err == nil || !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+}
+
+func TestAbort(t *testing.T) {
+	// This test requires a real program, so make sure it doesn't interfere with our test runner.
+	f := formProgram()
+
+	// Test that the form aborts without throwing a timeout error when explicitly told to abort.
+	ctx, cancel := context.WithCancel(context.Background())
+	// Since the context is cancelled, the program should exit immediately.
+	cancel()
+	// Tell the form to abort.
+	f.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	// Run the program.
+	err := f.RunWithContext(ctx)
+	if err == nil || !errors.Is(err, ErrUserAborted) {
+		t.Errorf("expected user aborted error, got %v", err)
+	}
+}
+
+type testColor string
+
+func (testColor) Values() []any {
+	return []any{testColor("red"), testColor("green"), testColor("blue")}
+}
+
+func TestFormFromStruct(t *testing.T) {
+	type Server struct {
+		Host string `huh:"title=Host,validate=notempty"`
+	}
+	type Config struct {
+		Name   string    `huh:"title=Name,placeholder=Jane"`
+		Shell  string    `huh:"title=Shell,options=bash|zsh|fish"`
+		Color  testColor `huh:"title=Color"`
+		Agree  bool      `huh:"title=Agree?"`
+		Skip   string    `huh:"-"`
+		Server Server    `huh:"title=Server"`
+	}
+
+	var cfg Config
+	f, err := NewFormFromStruct(&cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	view := ansi.Strip(f.View())
+	for _, s := range []string{"Name", "Shell", "zsh", "Color", "green", "Agree?"} {
+		if !strings.Contains(view, s) {
+			t.Log(pretty.Render(view))
+			t.Errorf("Expected form to contain %q", s)
+		}
+	}
+
+	f.Update(keys('J', 'o'))
+	f.Update(NextField())
+	f.Update(keys('j'))
+	f.Update(NextField())
+	f.Update(keys('j'))
+	f.Update(keys('j'))
+
+	if cfg.Name != "Jo" {
+		t.Errorf("Expected name to be Jo, got %q", cfg.Name)
+	}
+	if cfg.Shell != "zsh" {
+		t.Errorf("Expected shell to be zsh, got %q", cfg.Shell)
+	}
+	if cfg.Color != "blue" {
+		t.Errorf("Expected color to be blue, got %q", cfg.Color)
+	}
+	if f.selector.Total() != 2 {
+		t.Errorf("Expected 2 groups, got %d", f.selector.Total())
+	}
+	if key := f.selector.Get(1).selector.Get(0).GetKey(); key != "Server.Host" {
+		t.Errorf("Expected nested key to be Server.Host, got %q", key)
+	}
+
+	var tagged struct {
+		Name  string `huh:"title=Hello,, world,description=First,, last"`
+		Shell string `huh:"options=a||b|c"`
+		Size  uint64
+	}
+	f, err = NewFormFromStruct(&tagged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	group := f.selector.Get(0)
+	if input := group.selector.Get(0).(*Input); input.title.val != "Hello, world" || input.description.val != "First, last" {
+		t.Errorf("Expected doubled commas to be commas, got %q %q", input.title.val, input.description.val)
+	}
+	if s := group.selector.Get(1).(*Select[any]); len(s.options.val) != 2 || s.options.val[0].Key != "a|b" {
+		t.Errorf("Expected doubled pipes to be pipes, got %v", s.options.val)
+	}
+	if n := group.selector.Get(2).(*Number[int64]); !n.hasMax || n.maxValue != math.MaxInt64 {
+		t.Errorf("Expected uint64 fields to be bounded by the largest int64, got %d", n.maxValue)
+	}
+	var unbounded struct {
+		Size uint64 `huh:"max=18446744073709551615"`
+	}
+	if _, err := NewFormFromStruct(&unbounded); err == nil {
+		t.Error("Expected an error for a bound larger than the largest int64")
+	}
+
+	if _, err := NewFormFromStruct(cfg); err == nil {
+		t.Error("Expected an error for a non-pointer value")
+	}
+	var bad struct {
+		Callback func()
+	}
+	if _, err := NewFormFromStruct(&bad); err == nil {
+		t.Error("Expected an error for an unsupported field type")
+	}
+}
+
+func TestFormFromJSON(t *testing.T) {
+	const def = `{
+	  "groups": [{
+	    "title": "Account",
+	    "fields": [
+	      {"type": "input", "key": "name", "title": "Name", "default": "Jo", "validate": "notempty"},
+	      {"type": "select", "key": "plan", "title": "Plan", "options": [
+	        {"key": "Free", "value": "free"},
+	        {"key": "Pro", "value": "pro"}
+	      ]}
+	    ]
+	  }, {
+	    "hide": {"key": "plan", "equals": "free"},
+	    "fields": [{"type": "input", "key": "card", "title": "Card number"}]
+	  }, {
+	    "fields": [{"type": "confirm", "key": "terms", "title": "Accept terms?"}]
+	  }]
+	}`
+
+	f, err := NewFormFromJSON(strings.NewReader(def))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	view := ansi.Strip(f.View())
+	if !strings.Contains(view, "Name") || !strings.Contains(view, "Pro") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected form to contain the defined fields")
+	}
+
+	// Keep the free plan, which hides the card group.
+	m, cmd := f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = batchUpdate(m, cmd)
+	m, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = batchUpdate(m, cmd)
+
+	view = ansi.Strip(m.View())
+	if !strings.Contains(view, "Accept terms?") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the card group to be hidden")
+	}
+	if f.GetString("name") != "Jo" || f.GetString("plan") != "free" {
+		t.Errorf("Expected results to be keyed, got %v", f.results)
+	}
+
+	for _, def := range []string{
+		`{"groups": []}`,
+		`{"groups": [{"fields": [{"type": "input"}]}]}`,
+		`{"groups": [{"fields": [{"type": "slider", "key": "x"}]}]}`,
+		`{"groups": [{"hide": {"key": "nope", "equals": 1}, "fields": [{"type": "note"}]}]}`,
+		`{"groups": [{"fields": [{"type": "confirm", "key": "a"}, {"type": "note", "hide": {"key": "a"}}]}]}`,
+		`{"groups": [{"fields": [{"type": "confirm", "key": "a"}, {"type": "note", "hide": {"key": "a", "equals": true, "not_equals": false}}]}]}`,
+	} {
+		if _, err := NewFormFromJSON(strings.NewReader(def)); err == nil {
+			t.Errorf("Expected an error for definition %s", def)
+		}
+	}
+}
+
+func TestFormFromYAML(t *testing.T) {
+	def := `
+groups:
+  - title: Order
+    fields:
+      - type: input
+        key: name
+        title: Name
+        default: Jo
+      - type: multiselect
+        key: toppings
+        default: [cheese]
+        options:
+          - {key: Cheese, value: cheese}
+          - {key: Corn, value: corn}
+      - type: integer
+        key: count
+        default: 3
+      - type: date
+        key: day
+        default: 2024-12-31
+  - hide: {key: toppings, contains: cheese}
+    fields:
+      - type: confirm
+        key: vegan
+        title: Vegan cheese?
+`
+	f, err := NewFormFromYAML(strings.NewReader(def))
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := f.Results()
+	if results["name"] != "Jo" || results["count"] != 3 || !reflect.DeepEqual(results["toppings"], []string{"cheese"}) {
+		t.Errorf("Expected the defaults to be set, got %v", results)
+	}
+	if day, ok := results["day"].(time.Time); !ok || day.Format(time.DateOnly) != "2024-12-31" {
+		t.Errorf("Expected the default date to be set, got %v", results["day"])
+	}
+	if !f.isGroupHidden(f.selector.Get(1)) {
+		t.Error("Expected the group to be hidden by its condition")
+	}
+
+	for _, def := range []string{
+		"groups: []",
+		"groups: [{fields: [{type: input, key: a, colour: red}]}]",
+		"groups: [{hide: {key: a}, fields: [{type: input, key: a}]}]",
+	} {
+		if _, err := NewFormFromYAML(strings.NewReader(def)); err == nil {
+			t.Errorf("Expected an error for definition %s", def)
+		}
+	}
+}
+
+func TestAnswers(t *testing.T) {
+	newForm := func() (*Form, *string, *[]string, *bool) {
+		var (
+			name     string
+			toppings []string
+			discount bool
+		)
+		f := NewForm(
+			NewGroup(
+				NewInput().Key("name").Value(&name).Validate(ValidateMinLength(2)),
+				NewMultiSelect[string]().Key("toppings").Value(&toppings).
+					Options(NewOptions("lettuce", "tomatoes", "cheese")...),
+			),
+			NewGroup(
+				NewConfirm().Key("discount").Value(&discount),
+			),
+		).WithNonInteractive(true)
+		return f, &name, &toppings, &discount
+	}
+
+	f, name, toppings, discount := newForm()
+	t.Setenv("HUH_DISCOUNT", "yes")
+	err := f.WithAnswers(map[string]any{
+		"name":     "Glenn",
+		"toppings": "lettuce, cheese",
+	}).WithEnvAnswers().Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *name != "Glenn" || !*discount || strings.Join(*toppings, ",") != "lettuce,cheese" {
+		t.Errorf("Expected answers to be applied, got %q %v %v", *name, *toppings, *discount)
+	}
+	if f.State != StateCompleted || f.GetString("name") != "Glenn" {
+		t.Error("Expected form to be completed with results")
+	}
+
+	f, _, _, _ = newForm()
+	err = f.WithAnswers(map[string]any{"name": "G", "toppings": []string{"bacon"}}).Run()
+	var answersErr *AnswersError
+	if !errors.As(err, &answersErr) {
+		t.Fatalf("Expected an answers error, got %v", err)
+	}
+	if len(answersErr.Missing) != 1 || answersErr.Missing[0] != "discount" {
+		t.Errorf("Expected discount to be missing, got %v", answersErr.Missing)
+	}
+	if answersErr.Invalid["name"] == nil || answersErr.Invalid["toppings"] == nil {
+		t.Errorf("Expected name and toppings to be invalid, got %v", answersErr.Invalid)
+	}
+}
+
+func TestResults(t *testing.T) {
+	toppings := []string{"olives"}
+	f := NewForm(
+		NewGroup(
+			NewInput().Key("name"),
+			NewNote().Title("Not a value"),
+			NewSelect[int]().Key("age").Options(NewOption("Young", 10), NewOption("Old", 80)),
+		),
+		NewGroup(
+			NewMultiSelect[string]().Key("toppings").
+				Value(&toppings).
+				Options(NewOption("Cheese", "cheese").Selected(true), NewOption("Corn", "corn")),
+		),
+	)
+	if !reflect.DeepEqual(toppings, []string{"olives"}) {
+		t.Errorf("Expected the bound value to be left as it is until the form starts, got %v", toppings)
+	}
+	f = batchUpdate(f, f.Init()).(*Form)
+	if !reflect.DeepEqual(toppings, []string{"cheese"}) {
+		t.Errorf("Expected the bound value to hold the selected options, got %v", toppings)
+	}
+
+	// The user never leaves the name field.
+	f.Update(keys('J', 'o'))
+
+	results := f.Results()
+	if len(results) != 3 || results["name"] != "Jo" || results["age"] != 10 {
+		t.Errorf("Expected a snapshot of all keyed fields, got %v", results)
+	}
+
+	data, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"age":10,"name":"Jo","toppings":["cheese"]}` {
+		t.Errorf("Unexpected JSON results: %s", data)
+	}
+
+	data, err = yaml.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "age: 10\nname: Jo\ntoppings:\n    - cheese\n" {
+		t.Errorf("Unexpected YAML results: %s", data)
+	}
+
+	var order struct {
+		Name     string
+		Age      int
+		Toppings []string
+	}
+	if err := f.Unmarshal(&order); err != nil {
+		t.Fatal(err)
+	}
+	if order.Name != "Jo" || order.Age != 10 || len(order.Toppings) != 1 {
+		t.Errorf("Expected results to be unmarshaled, got %+v", order)
+	}
+}
+
+func TestMultiSelectOptionsFuncKeepsValue(t *testing.T) {
+	value := []string{"b"}
+	field := NewMultiSelect[string]().Value(&value).OptionsFunc(func() []Option[string] {
+		return NewOptions("a", "b")
+	}, nil)
+	_, cmd := field.Update(updateFieldMsg{})
+	for _, cmd := range cmd().(tea.BatchMsg) {
+		if msg, ok := cmd().(updateOptionsMsg[string]); ok {
+			field.Update(msg)
+		}
+	}
+	if !reflect.DeepEqual(value, []string{"b"}) {
+		t.Errorf("Expected the bound value to select the loaded options, got %v", value)
+	}
+	if !field.options.val[1].selected {
+		t.Error("Expected the bound option to be selected")
+	}
+}
+
+func TestGroupAndFormValidation(t *testing.T) {
+	var password, confirmation string
+	f := NewForm(
+		NewGroup(
+			NewInput().Key("password").Value(&password),
+			NewInput().Key("confirmation").Value(&confirmation),
+		).Validate(func() error {
+			if password != confirmation {
+				return errors.New("passwords do not match")
+			}
+			return nil
+		}),
+		NewGroup(
+			NewInput().Key("start"),
+			NewInput().Key("end"),
+		),
+	).Validate(func(results map[string]any) error {
+		if results["start"].(string) > results["end"].(string) {
+			return errors.New("start must be before end")
+		}
+		return nil
+	})
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	f.Update(keys('a'))
+	f.Update(NextField())
+	f.Update(keys('b'))
+	f.Update(nextGroup())
+
+	view := ansi.Strip(f.View())
+	if !strings.Contains(view, "passwords do not match") || f.selector.Index() != 0 {
+		t.Log(pretty.Render(view))
+		t.Fatal("Expected group validation to block the next group")
+	}
+
+	// Typing clears the error, fix the confirmation.
+	f.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	f.Update(keys('a'))
+	f.Update(nextGroup())
+	if f.selector.Index() != 1 {
+		t.Fatal("Expected form to move to the next group")
+	}
+
+	f.Update(keys('2'))
+	f.Update(NextField())
+	f.Update(keys('1'))
+	f.Update(nextGroup())
+
+	view = ansi.Strip(f.View())
+	if !strings.Contains(view, "start must be before end") || f.State != StateNormal {
+		t.Log(pretty.Render(view))
+		t.Fatal("Expected form validation to block submission")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	f.Update(keys('3'))
+	f.Update(nextGroup())
+	if f.State != StateCompleted {
+		t.Error("Expected form to be submitted")
+	}
+}
+
+func TestValidateAsync(t *testing.T) {
+	var contexts []context.Context
+	input := NewInput().ValidateAsync(func(ctx context.Context, s string) error {
+		contexts = append(contexts, ctx)
+		if s == "taken" {
+			return errors.New("username is taken")
+		}
+		return nil
+	})
+	input.WithKeyMap(NewDefaultKeyMap())
+	input.Focus()
+
+	// submitted returns whether submitting the input moves to the next field.
+	submitted := func() bool {
+		_, cmd := input.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if cmd == nil {
+			return false
+		}
+		_, ok := cmd().(nextFieldMsg)
+		return ok
+	}
+
+	// validate runs the pending validation and returns its result.
+	validate := func() tea.Msg {
+		_, cmd := input.Update(asyncValidateMsg{id: input.id, seq: input.validateAsync.seq})
+		for _, cmd := range cmd().(tea.BatchMsg) {
+			if msg, ok := cmd().(asyncValidatedMsg); ok {
+				return msg
+			}
+		}
+		t.Fatal("Expected validation to run")
+		return nil
+	}
+
+	for _, r := range "taken" {
+		input.Update(keys(r))
+	}
+	if !input.validateAsync.blocked() {
+		t.Fatal("Expected validation to be pending")
+	}
+	stale := validate()
+
+	// A new keystroke cancels the running validation and discards its result.
+	input.Update(keys('!'))
+	input.Update(stale)
+	if contexts[0].Err() == nil {
+		t.Error("Expected stale validation to be cancelled")
+	}
+	if !input.validateAsync.blocked() || input.Error() != nil {
+		t.Fatal("Expected stale validation to be discarded")
+	}
+	if submitted() {
+		t.Error("Expected submission to be blocked while validating")
+	}
+	if input.validateAsync.value != "taken!" {
+		t.Fatalf("Expected validation of the latest value, got %q", input.validateAsync.value)
+	}
+
+	input.Update(validate())
+	if input.validateAsync.blocked() || input.Error() != nil {
+		t.Fatal("Expected validation to pass")
+	}
+
+	input.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	input.Update(validate())
+	if err := input.Error(); err == nil || err.Error() != "username is taken" {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+	if submitted() {
+		t.Error("Expected submission to be blocked by the validation error")
+	}
+
+	input.Update(keys('!'))
+	input.Update(validate())
+	if !submitted() {
+		t.Error("Expected submission once the validation passes")
+	}
+}
+
+func TestConfirmAccessibleValidation(t *testing.T) {
+	var checked []bool
+	accepted := false
+	confirm := NewConfirm().Title("Accept the terms?").Value(&accepted).
+		Validate(func(v bool) error {
+			if !v {
+				return errors.New("the terms must be accepted")
+			}
+			return nil
+		}).
+		ValidateAsync(func(_ context.Context, v bool) error {
+			checked = append(checked, v)
+			return nil
+		})
+
+	var out strings.Builder
+	if err := confirm.RunAccessible(&out, strings.NewReader("n\ny\n")); err != nil {
+		t.Fatal(err)
+	}
+	if !accepted {
+		t.Error("Expected the valid answer to be stored")
+	}
+	if !strings.Contains(out.String(), "Invalid input: the terms must be accepted\n") {
+		t.Log(out.String())
+		t.Error("Expected the synchronous validation to reject the answer")
+	}
+	if !reflect.DeepEqual(checked, []bool{true}) {
+		t.Errorf("Expected only the valid answer to be validated asynchronously, got %v", checked)
+	}
+}
+
+func TestNumber(t *testing.T) {
+	value := 9
+	n := NewNumber[int]().Min(0).Max(10).Value(&value)
+	n.WithKeyMap(NewDefaultKeyMap())
+	n.Focus()
+
+	n.Update(tea.KeyMsg{Type: tea.KeyUp})
+	n.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if value != 10 {
+		t.Errorf("Expected value to be clamped to 10, got %d", value)
+	}
+	n.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if value != 9 || !strings.Contains(ansi.Strip(n.View()), "9") {
+		t.Errorf("Expected value to be decremented to 9, got %d", value)
+	}
+
+	n.Update(keys('5'))
+	n.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if err := n.Error(); err == nil || err.Error() != "must be at most 10" {
+		t.Errorf("Expected out of range error, got %v", err)
+	}
+	if value != 9 {
+		t.Errorf("Expected out of range value to be ignored, got %d", value)
+	}
+
+	f := NewNumber[float64]().Separators(',', '.').Step(0.1)
+	f.WithKeyMap(NewDefaultKeyMap())
+	f.Focus()
+	f.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	for _, r := range "1.234,5" {
+		f.Update(keys(r))
+	}
+	if v := f.GetValue(); v != 1234.5 {
+		t.Errorf("Expected 1234.5, got %v", v)
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyUp})
+	f.Update(tea.KeyMsg{Type: tea.KeyUp})
+	if v := f.GetValue(); v != 1234.7 {
+		t.Errorf("Expected 1234.7 after stepping, got %v", v)
+	}
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "1234,7") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the decimal separator to be used")
+	}
+
+	grouped := NewNumber[int]().Separators('.', ',')
+	for input, want := range map[string]int{"1,234": 1234, "-1,234,567": -1234567, "1 000": 1000} {
+		if v, err := grouped.parse(input); err != nil || v != want {
+			t.Errorf("Expected %q to be parsed as %d, got %d, %v", input, want, v, err)
+		}
+	}
+	for _, input := range []string{"1,5", ",,3", "12,34", "1,2345", "1,"} {
+		if v, err := grouped.parse(input); err == nil {
+			t.Errorf("Expected %q to be rejected, got %d", input, v)
+		}
+	}
+	if _, err := f.parse("1.23,5"); err == nil {
+		t.Error("Expected an incomplete digit group to be rejected")
+	}
+	for input, want := range map[string]int{"1e5": 100000, "1.5e1": 15, "2E3": 2000} {
+		if v, err := grouped.parse(input); err != nil || v != want {
+			t.Errorf("Expected %q to be parsed as %d, got %d, %v", input, want, v, err)
+		}
+	}
+	for input, want := range map[string]string{
+		"1.25e1": `"1.25e1" is not a whole number`,
+		"1e30":   `"1e30" is too large`,
+	} {
+		if _, err := grouped.parse(input); err == nil || err.Error() != want {
+			t.Errorf("Expected %q to be rejected with %q, got %v", input, want, err)
+		}
+	}
+
+	value = 4
+	even := NewNumber[int]().Title("Even").Min(1).Max(10).Value(&value).
+		Validate(func(v int) error {
+			if v%2 != 0 {
+				return errors.New("must be even")
+			}
+			return nil
+		})
+	var out strings.Builder
+	if err := even.RunAccessible(&out, strings.NewReader("abc\n20\n3\n\n")); err != nil {
+		t.Fatal(err)
+	}
+	if value != 4 {
+		t.Errorf("Expected the current value to be kept, got %d", value)
+	}
+	for _, want := range []string{
+		"Enter a whole number from 1 to 10.\n",
+		"Number [4]: Invalid input: \"abc\" is not a number\n",
+		"Invalid input: enter a number from 1 to 10\n",
+		"Invalid input: must be even\n",
+		"Answer: 4\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Log(out.String())
+			t.Errorf("Expected output to contain %q", want)
+		}
+	}
+
+	form := NewForm(NewGroup(NewNumber[int64]().Key("count"))).
+		WithAnswers(map[string]any{"count": "42"})
+	if err := form.Run(); err != nil {
+		t.Fatal(err)
+	}
+	if v := form.GetInt("count"); v != 42 {
+		t.Errorf("Expected GetInt to return 42, got %d", v)
+	}
+}
+
+func TestDatePicker(t *testing.T) {
+	date := time.Date(2024, time.January, 31, 0, 0, 0, 0, time.UTC)
+	d := NewDatePicker().
+		Title("Maintenance window").
+		Value(&date).
+		Min(time.Date(2024, time.January, 15, 0, 0, 0, 0, time.UTC)).
+		Max(time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC)).
+		WeekStart(time.Monday).
+		DisabledDates(func(t time.Time) bool {
+			return t.Weekday() == time.Saturday || t.Weekday() == time.Sunday
+		})
+	d.WithKeyMap(NewDefaultKeyMap())
+	d.Focus()
+
+	view := ansi.Strip(d.View())
+	if !strings.Contains(view, "January 2024") || !strings.Contains(view, "Mo Tu We Th Fr Sa Su") {
+		t.Log(pretty.Render(view))
+		t.Fatal("Expected calendar of January starting on Monday")
+	}
+	if !strings.Contains(view, " 1  2  3  4  5  6  7") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected January 1st 2024 to be on a Monday")
+	}
+
+	d.Update(keys(']'))
+	if want := time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC); !date.Equal(want) {
+		t.Errorf("Expected %s, got %s", want, date)
+	}
+	d.Update(keys(']'))
+	if want := time.Date(2024, time.March, 10, 0, 0, 0, 0, time.UTC); !date.Equal(want) {
+		t.Errorf("Expected date to be clamped to %s, got %s", want, date)
+	}
+
+	_, cmd := d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd != nil || d.Error() == nil {
+		t.Error("Expected disabled date to be rejected")
+	}
+
+	d.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	d.Update(tea.KeyMsg{Type: tea.KeyLeft})
+	_, cmd = d.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("Expected %s to be accepted", date)
+	}
+	if _, ok := cmd().(nextFieldMsg); !ok || date.Day() != 8 {
+		t.Errorf("Expected March 8th to be submitted, got %s", date)
+	}
+
+	at := time.Date(2024, time.March, 8, 10, 7, 0, 0, time.UTC)
+	dt := NewDateTime().Value(&at).MinuteStep(15)
+	dt.WithKeyMap(NewDefaultKeyMap())
+	dt.Update(keys(':'))
+	dt.Update(tea.KeyMsg{Type: tea.KeyUp})
+	dt.Update(tea.KeyMsg{Type: tea.KeyRight})
+	dt.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if want := time.Date(2024, time.March, 8, 11, 45, 0, 0, time.UTC); !at.Equal(want) {
+		t.Errorf("Expected %s, got %s", want, at)
+	}
+
+	var unset time.Time
+	picker := NewDatePicker().Value(&unset)
+	picker.WithKeyMap(NewDefaultKeyMap())
+	picker.Focus()
+	picker.Update(keys('q'))
+	if !unset.IsZero() {
+		t.Errorf("Expected focusing not to pick a date, got %s", unset)
+	}
+	picker.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if today := picker.truncate(time.Now()); !unset.Equal(today) {
+		t.Errorf("Expected submitting to pick today, got %s", unset)
+	}
+}
+
+// formProgram returns a new Form with a nil input and output, so it can be used as a test program.
+func formProgram() *Form {
+	return NewForm(NewGroup(NewInput().Title("Foo"))).
+		WithInput(nil).WithOutput(io.Discard).
+		WithAccessible(false)
+}
+
+func batchUpdate(m tea.Model, cmd tea.Cmd) tea.Model {
+	if cmd == nil {
+		return m
+	}
+	msg := cmd()
+	m, cmd = m.Update(msg)
+	if cmd == nil {
+		return m
+	}
+	msg = cmd()
+	m, cmd = m.Update(msg)
+	return m
+}
+
+func TestFilter(t *testing.T) {
+	if m, ok := FilterFuzzy("nyc", "New York City"); !ok || !reflect.DeepEqual(m.Indexes, []int{0, 4, 9}) {
+		t.Errorf("Expected fuzzy match on word starts, got %v %v", m, ok)
+	}
+	if _, ok := FilterFuzzy("cyn", "New York City"); ok {
+		t.Error("Expected out of order characters not to match")
+	}
+	if _, ok := FilterPrefix("york", "New York"); ok {
+		t.Error("Expected prefix filter to only match the start of the key")
+	}
+	if _, ok := FilterContains("sao", "São Paulo"); ok {
+		t.Error("Expected diacritics to matter by default")
+	}
+	if m, ok := FilterIgnoreDiacritics(FilterContains)("SAO", "São Paulo"); !ok || !reflect.DeepEqual(m.Indexes, []int{0, 1, 2}) {
+		t.Errorf("Expected diacritic-insensitive match, got %v %v", m, ok)
+	}
+
+	var region string
+	field := NewSelect[string]().
+		Options(NewOptions("Central Canada", "Ireland", "Canada West")...).
+		Filter(FilterFuzzy).
+		Value(&region)
+	field.WithKeyMap(NewDefaultKeyMap())
+	field.Focus()
+
+	field.Update(keys('/'))
+	for _, r := range "ca" {
+		field.Update(keys(r))
+	}
+	var got []string
+	for _, option := range field.filteredOptions {
+		got = append(got, option.Key)
+	}
+	if want := []string{"Canada West", "Central Canada"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected matches ranked %v, got %v", want, got)
+	}
+	field.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if region != "Canada West" {
+		t.Errorf("Expected best match to be selected, got %q", region)
+	}
+
+	// A nil filter is the default one.
+	multi := NewMultiSelect[string]().Options(NewOptions("Ireland", "Iceland")...).Filter(nil)
+	multi.WithKeyMap(NewDefaultKeyMap())
+	multi.Focus()
+	multi.Update(keys('/'))
+	multi.Update(keys('c'))
+	if len(multi.filteredOptions) != 1 || multi.filteredOptions[0].Key != "Iceland" {
+		t.Errorf("Expected a nil filter to match with FilterContains, got %v", multi.filteredOptions)
+	}
+	field.Filter(nil)
+	field.Update(keys('/'))
+	field.Update(keys('i'))
+	tree := NewTreeSelect[string]().Nodes(NewTreeNode("Ireland", "ie")).Filter(nil)
+	if tree.filterFn == nil || field.filterFn == nil {
+		t.Error("Expected a nil filter to be the default one")
+	}
+}
+
+func TestOptionsSource(t *testing.T) {
+	var queries []OptionQuery
+	source := OptionSourceFunc[int](func(_ context.Context, q OptionQuery) (OptionPage[int], error) {
+		queries = append(queries, q)
+		var matches []Option[int]
+		for i := 0; i < 1000; i++ {
+			if key := fmt.Sprintf("item %d", i); strings.Contains(key, q.Filter) {
+				matches = append(matches, NewOption(key, i))
+			}
+		}
+		end := min(q.Offset+q.Limit, len(matches))
+		return OptionPage[int]{Options: matches[q.Offset:end], Total: len(matches)}, nil
+	})
+
+	value := 750
+	field := NewSelect[int]().OptionsSource(source).Value(&value)
+	field.WithKeyMap(NewDefaultKeyMap())
+	field.Focus()
+
+	var pump func(tea.Cmd)
+	pump = func(cmd tea.Cmd) {
+		if cmd == nil {
+			return
+		}
+		switch msg := cmd().(type) {
+		case tea.BatchMsg:
+			for _, cmd := range msg {
+				pump(cmd)
+			}
+		case optionPageMsg[int], optionFilterMsg:
+			_, cmd := field.Update(msg)
+			pump(cmd)
+			_, cmd = field.Update(updateFieldMsg{})
+			pump(cmd)
+		}
+	}
+	update := func(msg tea.Msg) {
+		_, cmd := field.Update(msg)
+		pump(cmd)
+		_, cmd = field.Update(updateFieldMsg{})
+		pump(cmd)
+	}
+
+	update(updateFieldMsg{})
+	if len(queries) != 1 || queries[0].Offset != 0 {
+		t.Fatalf("Expected only the first page to be fetched, got %v", queries)
+	}
+	if value != 750 {
+		t.Errorf("Expected value not loaded yet to be kept, got %d", value)
+	}
+	if view := ansi.Strip(field.View()); !strings.Contains(view, "item 0") || strings.Contains(view, "item 100") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the first options to be shown")
+	}
+
+	// Moving to the last option fetches its page.
+	update(tea.KeyMsg{Type: tea.KeyUp})
+	if last := queries[len(queries)-1]; last.Offset != 900 {
+		t.Errorf("Expected the last page to be fetched, got %v", queries)
+	}
+	if value != 999 {
+		t.Errorf("Expected the last option to be selected once loaded, got %d", value)
+	}
+
+	update(keys('/'))
+	field.Update(keys('9'))
+	field.Update(updateFieldMsg{})
+	update(keys('9'))
+	if last := queries[len(queries)-1]; last.Filter != "99" {
+		t.Errorf("Expected the filter to be passed to the source, got %q", last.Filter)
+	}
+	for _, q := range queries {
+		if q.Filter == "9" {
+			t.Errorf("Expected the filter to settle before fetching, got %v", queries)
+		}
+	}
+	if n := field.source.Len(); n != 19 {
+		t.Errorf("Expected 19 matches, got %d", n)
+	}
+	if len(field.source.pages) != 1 {
+		t.Errorf("Expected only the pages loaded to be kept, got %d", len(field.source.pages))
+	}
+
+	// Only the options in view are rendered.
+	update(tea.KeyMsg{Type: tea.KeyEsc})
+	update(tea.KeyMsg{Type: tea.KeyEnd})
+	view := ansi.Strip(field.View())
+	if !strings.Contains(view, "item 999") || strings.Contains(view, "item 0") || lipgloss.Height(view) > defaultHeight {
+		t.Log(pretty.Render(view))
+		t.Error("Expected the last options to be in view")
+	}
+}
+
+func TestAccessibleIO(t *testing.T) {
+	var (
+		name  = "Grace"
+		more  bool
+		extra string
+		color string
+	)
+	form := NewForm(
+		NewGroup(
+			NewInput().Title("Name").Description("Your first name.").Value(&name),
+			NewConfirm().Title("More?").Value(&more),
+		),
+		NewGroup(
+			NewInput().Title("Extra").Value(&extra),
+		).WithHideFunc(func() bool { return !more }),
+		NewGroup(
+			NewNote().Title("Almost done").Description("*One* more question."),
+			NewSelect[string]().Title("Color").Options(NewOptions("red", "green", "blue")...).Value(&color),
+		),
+	)
+
+	var out strings.Builder
+	err := form.WithAccessible(true).
+		WithInput(strings.NewReader("\nmaybe\nn\n4\n2\n")).
+		WithOutput(&out).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Grace" || more || extra != "" || color != "green" {
+		t.Errorf("Unexpected answers %q %v %q %q", name, more, extra, color)
+	}
+
+	output := out.String()
+	for _, want := range []string{
+		"Question 1 of 3.\nName\nYour first name.\nInput [Grace]: Answer: Grace\n",
+		"Choose [n]: Invalid input: enter y or n\n",
+		"Almost done\nOne more question.\n",
+		"Question 3 of 3.\nColor\n",
+		"Invalid input: enter a number from 1 to 3\n",
+		"Answer: green\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Log(output)
+			t.Errorf("Expected output to contain %q", want)
+		}
+	}
+	if strings.Contains(output, "Extra") {
+		t.Error("Expected hidden group not to be prompted")
+	}
+	if strings.Contains(output, "\x1b") {
+		t.Error("Expected plain text without escape codes")
+	}
+
+	err = NewForm(NewGroup(NewInput().Title("Name"))).
+		WithAccessible(true).
+		WithInput(strings.NewReader("")).
+		WithOutput(io.Discard).
+		Run()
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("Expected running out of input to be an error, got %v", err)
+	}
+}
+
+// runOnlyField is a field implemented outside of huh, which only runs with
+// Run in accessible mode.
+type runOnlyField struct {
+	Field
+	ran bool
+}
+
+func (f *runOnlyField) WithAccessible(bool) Field { return f }
+
+func (f *runOnlyField) Run() error {
+	f.ran = true
+	return nil
+}
+
+func TestAccessibleCustomField(t *testing.T) {
+	field := &runOnlyField{Field: NewNote().Title("Custom")}
+	err := NewForm(NewGroup(field)).
+		WithAccessible(true).
+		WithInput(strings.NewReader("")).
+		WithOutput(io.Discard).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !field.ran {
+		t.Error("Expected a field without RunAccessible to be run with Run")
+	}
+}
+
+func TestReview(t *testing.T) {
+	var (
+		name  string
+		shell string
+	)
+	f := NewForm(
+		NewGroup(
+			NewInput().Key("name").Title("Name").Value(&name),
+			NewSelect[string]().Key("shell").Title("Shell").Options(NewOptions("Soft", "Hard")...).Value(&shell),
+		),
+		NewGroup(NewConfirm().Key("confirm").Title("Confirm?")),
+		NewGroup(NewInput().Key("secret").Title("Secret")).WithHide(true),
+	).WithReview(true)
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	f.Update(keys('a'))
+	f.Update(NextField())
+	f.Update(nextGroup())
+	f.Update(nextGroup())
+
+	view := ansi.Strip(f.View())
+	if !f.Reviewing() || f.State != StateNormal {
+		t.Log(pretty.Render(view))
+		t.Fatal("Expected the review page instead of submitting")
+	}
+	for _, want := range []string{"Review your answers", "> Name      a", "  Shell     Soft", "  Confirm?  No"} {
+		if !strings.Contains(view, want) {
+			t.Log(pretty.Render(view))
+			t.Errorf("Expected review page to contain %q", want)
+		}
+	}
+	if strings.Contains(view, "Secret") {
+		t.Error("Expected hidden groups not to be reviewed")
+	}
+
+	// Edit the shell, then come back to the review page.
+	f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if f.Reviewing() || f.selector.Index() != 0 || f.GetFocusedField().GetKey() != "shell" {
+		t.Fatal("Expected to edit the picked answer")
+	}
+	f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	f.Update(nextGroup())
+	if view := ansi.Strip(f.View()); !f.Reviewing() || !strings.Contains(view, "Shell     Hard") {
+		t.Log(pretty.Render(view))
+		t.Fatal("Expected to return to the review page with the new answer")
+	}
+
+	f.Update(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if f.Reviewing() || f.selector.Index() != 1 {
+		t.Fatal("Expected to go back to the last visible group")
+	}
+	f.Update(nextGroup())
+	f.Update(keys('s'))
+	if f.State != StateCompleted || name != "a" || shell != "Hard" {
+		t.Errorf("Expected the form to be submitted from the review page, got %q %q", name, shell)
+	}
+
+	var out strings.Builder
+	name = ""
+	err := NewForm(NewGroup(NewInput().Key("name").Title("Name").Value(&name))).
+		WithReview(true).
+		WithAccessible(true).
+		WithInput(strings.NewReader("Grace\n1\nAda\n\n")).
+		WithOutput(&out).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Ada" {
+		t.Errorf("Expected the answer to be changed, got %q", name)
+	}
+	if output := out.String(); !strings.Contains(output, "1. Name: Grace\nChange [0]: ") || !strings.Contains(output, "1. Name: Ada\n") {
+		t.Log(output)
+		t.Error("Expected the answers to be reviewed")
+	}
+}
+
+func TestReviewShowsGroups(t *testing.T) {
+	var (
+		wine bool
+		age  string
+	)
+	f := NewForm(
+		NewGroup(NewConfirm().Key("wine").Title("Wine?").Value(&wine)),
+		NewGroup(NewInput().Key("age").Title("Age").Value(&age)).WithHideFunc(func() bool { return !wine }),
+		NewGroup(NewInput().Key("note").Title("Note")),
+	).WithReview(true)
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	f.Update(nextGroup())
+	f.Update(nextGroup())
+	if !f.Reviewing() {
+		t.Fatal("Expected the review page")
+	}
+
+	// Changing the answer shows the age group, which is asked before going
+	// back to the review page.
+	f.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	f.Update(keys('y'))
+	f.Update(nextGroup())
+	if f.Reviewing() || f.selector.Index() != 1 {
+		t.Fatal("Expected the group shown by the new answer to be asked")
+	}
+	f.Update(keys('4', '2'))
+	f.Update(nextGroup())
+	if view := ansi.Strip(f.View()); !f.Reviewing() || !strings.Contains(view, "Age    42") {
+		t.Log(pretty.Render(view))
+		t.Fatal("Expected to return to the review page with the new group answered")
+	}
+
+	var out strings.Builder
+	wine, age = false, ""
+	err := NewForm(
+		NewGroup(NewConfirm().Key("wine").Title("Wine?").Value(&wine)),
+		NewGroup(NewInput().Key("age").Title("Age").Value(&age)).WithHideFunc(func() bool { return !wine }),
+	).
+		WithReview(true).
+		WithAccessible(true).
+		WithInput(strings.NewReader("n\n1\ny\n42\n\n")).
+		WithOutput(&out).
+		Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !wine || age != "42" {
+		t.Log(out.String())
+		t.Errorf("Expected the shown group to be asked, got %v %q", wine, age)
+	}
+}
+
+func TestProgress(t *testing.T) {
+	var free bool
+	f := NewForm(
+		NewGroup(NewInput().Title("Name")).Title("Account"),
+		NewGroup(NewInput().Title("Card")).Title("Payment").WithHideFunc(func() bool { return free }),
+		NewGroup(NewInput().Title("Email")),
+	).WithProgress(ProgressCounter)
+	f = batchUpdate(f, f.Init()).(*Form)
+
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "Step 1 of 3") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected a step counter")
+	}
+
+	free = true
+	f.WithProgress(ProgressBreadcrumbs)
+	if view := ansi.Strip(f.View()); !strings.Contains(view, "Account › Step 2") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected breadcrumbs without the hidden group")
+	}
+
+	f.WithProgress(ProgressBar).WithLayout(LayoutColumns(2))
+	f.Update(nextGroup())
+	view := ansi.Strip(f.View())
+	if !strings.Contains(view, strings.Repeat("█", 30)+" 2/2") || !strings.Contains(view, "Email") {
+		t.Log(pretty.Render(view))
+		t.Error("Expected a full progress bar on the last group")
+	}
+}
+
+func TestPersistence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "answers.json")
+	newForm := func() *Form {
+		return NewForm(
+			NewGroup(
+				NewInput().Key("name"),
+				NewInput().Key("password").EchoMode(EchoModePassword),
+				NewInput().Key("secret"),
+				NewSelect[string]().Key("shell").Options(NewOptions("Soft", "Hard")...),
+				NewSelect[int]().Key("budget").Options(NewOption("Small", 1000), NewOption("Large", 1000000)),
+			),
+		).WithPersistenceFile(path).WithPersistenceExclude("secret")
+	}
+
+	f := newForm()
+	f = batchUpdate(f, f.Init()).(*Form)
+	f.Update(keys('G', 'l'))
+	f.Update(NextField())
+	f.Update(keys('p', 'w'))
+	f.Update(NextField())
+	f.Update(keys('s'))
+	f.Update(NextField())
+	f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	f.Update(NextField())
+	f.Update(tea.KeyMsg{Type: tea.KeyDown})
+	f.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+	if f.State != StateAborted {
+		t.Fatal("Expected the form to be aborted")
+	}
+
+	answers, err := NewFileStore(path).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]any{"name": "Gl", "shell": "Hard", "budget": 1e6}; !reflect.DeepEqual(answers, want) {
+		t.Errorf("Expected saved answers %v, got %v", want, answers)
+	}
+
+	f = newForm()
+	f = batchUpdate(f, f.Init()).(*Form)
+	if f.GetString("name") != "Gl" || f.GetString("shell") != "Hard" || f.GetString("password") != "" || f.GetInt("budget") != 1000000 {
+		t.Errorf("Expected answers to be restored, got %v", f.Results())
+	}
+	f.Update(nextGroup())
+	if f.State != StateCompleted {
+		t.Fatal("Expected the form to be completed")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("Expected saved answers to be cleared, got %v", err)
+	}
+}
+
+// failingStore is a store failing to save answers.
+type failingStore struct{}
+
+func (failingStore) Load() (map[string]any, error) { return nil, nil }
+
+func (failingStore) Save(map[string]any) error { return errors.New("disk full") }
+
+func (failingStore) Clear() error { return nil }
+
+func TestPersistenceSaveError(t *testing.T) {
+	f := NewForm(NewGroup(NewInput().Key("name"))).WithPersistence(failingStore{})
+	f = batchUpdate(f, f.Init()).(*Form)
+	f.Update(keys('G'))
+	f.Update(NextField())
+	f.Update(nextGroup())
+	if f.State != StateCompleted {
+		t.Fatal("Expected the form to be completed")
+	}
+	if err := f.persistErr; err == nil || err.Error() != "huh: saving answers: disk full" {
+		t.Errorf("Expected the save error to be kept once the answers are cleared, got %v", err)
 	}
-	msg = cmd()
-	m, cmd = m.Update(msg)
-	return m
 }
 
 func keys(runes ...rune) tea.KeyMsg {
@@ -0,0 +1,414 @@
+// Package huhtest drives forms in tests without a terminal.
+//
+// A Driver wraps a form and acts on it the way a user would: typing text,
+// picking options and submitting groups. Every action runs the commands the
+// form returns, and the messages they produce, until the form settles, so
+// that views and results can be checked right after it.
+//
+//	var name string
+//	form := huh.NewForm(huh.NewGroup(huh.NewInput().Title("Name").Value(&name)))
+//
+//	d := huhtest.New(t, form)
+//	d.Type("Glenn").Submit()
+//	if name != "Glenn" {
+//		t.Errorf("expected Glenn, got %q", name)
+//	}
+package huhtest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/thedeveloper-sharath/huh"
+)
+
+// UpdateEnv is the environment variable which, when set to a non-empty
+// value, makes Golden write the golden files instead of comparing them, as in
+// HUHTEST_UPDATE=1 go test ./...
+const UpdateEnv = "HUHTEST_UPDATE"
+
+const (
+	// defaultTimeout is how long a driver waits for a command by default.
+	defaultTimeout = 5 * time.Second
+
+	// maxRounds is the number of times in a row commands may return more
+	// commands before the driver gives up on the form settling.
+	maxRounds = 100
+
+	// maxSteps is the number of fields a driver moves through looking for
+	// an option or submitting a group before giving up.
+	maxSteps = 1000
+)
+
+// Driver drives a form in a test.
+//
+// Actions press the keys of the default key map, or of the key map set with
+// WithKeyMap. Commands are run until they return, except for those animating
+// cursors and spinners, which are dropped so that views don't change with
+// time.
+type Driver struct {
+	t       testing.TB
+	form    *huh.Form
+	keymap  *huh.KeyMap
+	timeout time.Duration
+}
+
+// New returns a driver for the form, initialized with an 80x24 window.
+func New(t testing.TB, form *huh.Form) *Driver {
+	t.Helper()
+	d := &Driver{t: t, form: form, keymap: huh.NewDefaultKeyMap(), timeout: defaultTimeout}
+	d.run(form.Init())
+	return d.Resize(80, 24)
+}
+
+// WithTimeout sets how long the driver waits for a command to return before
+// failing the test.
+func (d *Driver) WithTimeout(timeout time.Duration) *Driver {
+	d.timeout = timeout
+	return d
+}
+
+// WithKeyMap sets the key map of the form, whose keys the driver presses.
+func (d *Driver) WithKeyMap(keymap *huh.KeyMap) *Driver {
+	d.keymap = keymap
+	d.form.WithKeyMap(keymap)
+	return d
+}
+
+// Form returns the form being driven.
+func (d *Driver) Form() *huh.Form {
+	return d.form
+}
+
+// Send sends a message to the form and runs the commands it returns.
+func (d *Driver) Send(msg tea.Msg) *Driver {
+	d.t.Helper()
+	_, cmd := d.form.Update(msg)
+	d.run(cmd)
+	return d
+}
+
+// Resize resizes the window the form is displayed in.
+func (d *Driver) Resize(width, height int) *Driver {
+	d.t.Helper()
+	return d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// Type types text into the focused field, one key at a time.
+func (d *Driver) Type(text string) *Driver {
+	d.t.Helper()
+	for _, r := range text {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+		if r == ' ' {
+			msg.Type = tea.KeySpace
+		}
+		d.Send(msg)
+	}
+	return d
+}
+
+// Press presses keys by name, such as "enter", "ctrl+a", "alt+enter", "x" or
+// "space".
+func (d *Driver) Press(keys ...string) *Driver {
+	d.t.Helper()
+	for _, k := range keys {
+		msg, ok := keyMsg(k)
+		if !ok {
+			d.t.Fatalf("huhtest: unknown key %q", k)
+		}
+		d.Send(msg)
+	}
+	return d
+}
+
+// pressBinding presses the first key of a key binding.
+func (d *Driver) pressBinding(binding key.Binding) *Driver {
+	d.t.Helper()
+	keys := binding.Keys()
+	if len(keys) == 0 {
+		d.t.Fatalf("huhtest: binding %q has no keys", binding.Help().Desc)
+	}
+	return d.Press(keys[0])
+}
+
+// hoverer is implemented by fields with a cursor over their options.
+type hoverer interface {
+	HoveredKey() string
+}
+
+// SelectOption moves the cursor of the focused select or multi-select field
+// to the option with the given label. A select field's value follows its
+// cursor.
+func (d *Driver) SelectOption(label string) *Driver {
+	d.t.Helper()
+	field, ok := d.form.GetFocusedField().(hoverer)
+	if !ok {
+		d.t.Fatalf("huhtest: focused field %T has no options", d.form.GetFocusedField())
+	}
+
+	d.Press("home")
+	first := field.HoveredKey()
+	for i := 0; i < maxSteps; i++ {
+		hovered := field.HoveredKey()
+		if hovered == label {
+			return d
+		}
+		d.Press("down")
+		// The cursor either wrapped around or stopped at the end.
+		if next := field.HoveredKey(); next == first || next == hovered {
+			break
+		}
+	}
+	d.t.Fatalf("huhtest: no option %q", label)
+	return d
+}
+
+// Toggle toggles the options with the given labels of the focused
+// multi-select field.
+func (d *Driver) Toggle(labels ...string) *Driver {
+	d.t.Helper()
+	for _, label := range labels {
+		d.SelectOption(label).Press("space")
+	}
+	return d
+}
+
+// Next completes the focused field, moving on to the next one if its value
+// is valid.
+func (d *Driver) Next() *Driver {
+	d.t.Helper()
+	return d.Press("enter")
+}
+
+// Submit completes the fields of the focused group in turn, moving on to the
+// next group, or completing the form, once they are all valid. It stops when
+// a field or the group itself does not validate; see Errors.
+//
+// On the review page of a form, Submit submits the form.
+func (d *Driver) Submit() *Driver {
+	d.t.Helper()
+	if d.form.Reviewing() {
+		return d.pressBinding(d.keymap.Review.Submit)
+	}
+	group := d.form.GetFocusedGroup()
+	for i := 0; i < maxSteps; i++ {
+		if d.form.State != huh.StateNormal || d.form.Reviewing() || d.form.GetFocusedGroup() != group {
+			return d
+		}
+		field, view := d.form.GetFocusedField(), d.View()
+		d.Next()
+		if d.form.GetFocusedField() == field && d.View() == view {
+			return d
+		}
+	}
+	d.t.Fatalf("huhtest: group was not submitted after %d steps", maxSteps)
+	return d
+}
+
+// Back moves back to the previous field, or to the previous group from the
+// first field of a group.
+func (d *Driver) Back() *Driver {
+	d.t.Helper()
+	return d.Send(huh.PrevField())
+}
+
+// View returns the form's view without styles, and without trailing spaces
+// on its lines.
+func (d *Driver) View() string {
+	lines := strings.Split(ansi.Strip(d.form.View()), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Results returns the current values of the form's fields, by key.
+func (d *Driver) Results() map[string]any {
+	return d.form.Results()
+}
+
+// Errors returns the errors of the focused group.
+func (d *Driver) Errors() []error {
+	return d.form.Errors()
+}
+
+// Golden compares the form's view with the golden file testdata/name.golden,
+// writing the file instead when UpdateEnv is set.
+func (d *Driver) Golden(name string) {
+	d.t.Helper()
+	Golden(d.t, name, d.View())
+}
+
+// Golden compares got with the golden file testdata/name.golden, writing the
+// file instead when UpdateEnv is set.
+func Golden(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("huhtest: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil { //nolint:gosec
+			t.Fatalf("huhtest: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("huhtest: %v (run with %s=1 to create it)", err, UpdateEnv)
+	}
+	if !bytes.Equal(want, []byte(got)) {
+		t.Errorf("huhtest: output does not match %s\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
+
+// run runs a command and the commands returned by the messages it produces
+// until there are none left.
+//
+// Commands started together run concurrently, as they would in a program,
+// but their messages are delivered in order so that the outcome does not
+// depend on which returns first.
+func (d *Driver) run(cmd tea.Cmd) {
+	d.t.Helper()
+	cmds := []tea.Cmd{cmd}
+	for round := 0; len(cmds) > 0; round++ {
+		if round == maxRounds {
+			d.t.Fatalf("huhtest: form did not settle after %d rounds of commands", maxRounds)
+		}
+
+		var next []tea.Cmd
+		for _, msg := range d.exec(cmds) {
+			switch msg := msg.(type) {
+			case nil, tea.QuitMsg:
+			case tea.BatchMsg:
+				next = append(next, msg...)
+			default:
+				if animation(msg) {
+					continue
+				}
+				if seq, ok := sequence(msg); ok {
+					next = append(next, seq...)
+					continue
+				}
+				_, cmd := d.form.Update(msg)
+				next = append(next, cmd)
+			}
+		}
+		cmds = next
+	}
+}
+
+// exec runs commands concurrently and returns their messages in order.
+func (d *Driver) exec(cmds []tea.Cmd) []tea.Msg {
+	d.t.Helper()
+	results := make([]chan tea.Msg, len(cmds))
+	for i, cmd := range cmds {
+		if cmd == nil || animates(cmd) {
+			continue
+		}
+		results[i] = make(chan tea.Msg, 1)
+		go func(cmd tea.Cmd, result chan<- tea.Msg) {
+			result <- cmd()
+		}(cmd, results[i])
+	}
+
+	timeout := time.NewTimer(d.timeout)
+	defer timeout.Stop()
+
+	msgs := make([]tea.Msg, 0, len(cmds))
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		select {
+		case msg := <-result:
+			msgs = append(msgs, msg)
+		case <-timeout.C:
+			d.t.Fatalf("huhtest: command did not return within %s", d.timeout)
+		}
+	}
+	return msgs
+}
+
+// blinkCmd is the code of the command blinking a cursor, which waits for the
+// blink before returning.
+var blinkCmd = func() uintptr {
+	c := cursor.New()
+	return reflect.ValueOf(c.Focus()).Pointer()
+}()
+
+// initialBlinkMsg is the type of the message starting to blink a cursor,
+// which is not exported.
+var initialBlinkMsg = reflect.TypeOf(cursor.Blink())
+
+// animates returns whether the command blinks a cursor.
+func animates(cmd tea.Cmd) bool {
+	return reflect.ValueOf(cmd).Pointer() == blinkCmd
+}
+
+// animation returns whether the message blinks a cursor or ticks a spinner,
+// which would start the animation again.
+func animation(msg tea.Msg) bool {
+	switch msg.(type) {
+	case cursor.BlinkMsg, spinner.TickMsg:
+		return true
+	}
+	return reflect.TypeOf(msg) == initialBlinkMsg
+}
+
+// sequence returns the commands of a message produced by tea.Sequence, whose
+// type is not exported.
+func sequence(msg tea.Msg) ([]tea.Cmd, bool) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Slice || v.Type().Elem() != reflect.TypeOf(tea.Cmd(nil)) {
+		return nil, false
+	}
+	cmds := make([]tea.Cmd, v.Len())
+	for i := range cmds {
+		cmds[i] = v.Index(i).Interface().(tea.Cmd)
+	}
+	return cmds, true
+}
+
+// keyNames maps key names to their types.
+var keyNames = func() map[string]tea.KeyType {
+	names := map[string]tea.KeyType{"space": tea.KeySpace}
+	for k := tea.KeyType(-256); k < 256; k++ {
+		if name := k.String(); name != "" {
+			if _, ok := names[name]; !ok {
+				names[name] = k
+			}
+		}
+	}
+	return names
+}()
+
+// keyMsg returns the message for a key name.
+func keyMsg(name string) (tea.KeyMsg, bool) {
+	var alt bool
+	if _, ok := keyNames[name]; !ok && strings.HasPrefix(name, "alt+") {
+		name, alt = strings.TrimPrefix(name, "alt+"), true
+	}
+	if k, ok := keyNames[name]; ok {
+		msg := tea.KeyMsg{Type: k, Alt: alt}
+		if k == tea.KeySpace {
+			msg.Runes = []rune{' '}
+		}
+		return msg, true
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: runes, Alt: alt}, true
+	}
+	return tea.KeyMsg{}, false
+}
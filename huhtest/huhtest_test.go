@@ -0,0 +1,104 @@
+package huhtest
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/thedeveloper-sharath/huh"
+)
+
+func TestDriver(t *testing.T) {
+	var (
+		name     string
+		shell    string
+		toppings []string
+		confirm  bool
+	)
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Key("name").
+				Title("Name").
+				Validate(func(s string) error {
+					if s == "" {
+						return errors.New("name is required")
+					}
+					return nil
+				}).
+				Value(&name),
+			huh.NewSelect[string]().
+				Key("shell").
+				Title("Shell").
+				Options(huh.NewOptions("Soft", "Hard", "Crunchy")...).
+				Value(&shell),
+			huh.NewMultiSelect[string]().
+				Key("toppings").
+				Title("Toppings").
+				Options(huh.NewOptions("Lettuce", "Cheese", "Salsa")...).
+				Value(&toppings),
+		),
+		huh.NewGroup(
+			huh.NewConfirm().
+				Key("confirm").
+				TitleFunc(func() string {
+					return "Order a " + strings.ToLower(shell) + " taco for " + name + "?"
+				}, &shell).
+				Value(&confirm),
+		),
+	)
+
+	d := New(t, form)
+
+	d.Submit()
+	if errs := d.Errors(); len(errs) != 1 || errs[0].Error() != "name is required" {
+		t.Fatalf("expected a required name error, got %v", errs)
+	}
+	if !strings.Contains(d.View(), "name is required") {
+		t.Errorf("expected the error in the view:\n%s", d.View())
+	}
+
+	d.Type("Glenn").Next()
+	d.SelectOption("Crunchy").Next()
+	d.Toggle("Salsa", "Lettuce").Submit()
+
+	if view := d.View(); !strings.Contains(view, "Order a crunchy taco for Glenn?") {
+		t.Errorf("expected the title to be evaluated:\n%s", view)
+	}
+	d.Golden("driver")
+
+	d.Back()
+	if !strings.Contains(d.View(), "Toppings") {
+		t.Errorf("expected to be back on the first group:\n%s", d.View())
+	}
+	d.Submit().Press("left").Submit()
+
+	if form.State != huh.StateCompleted {
+		t.Fatalf("expected the form to be completed, got %v", form.State)
+	}
+	if name != "Glenn" || shell != "Crunchy" || !confirm {
+		t.Errorf("unexpected values %q, %q, %v", name, shell, confirm)
+	}
+	if toppings := d.Results()["toppings"].([]string); !slices.Equal(toppings, []string{"Lettuce", "Salsa"}) {
+		t.Errorf("expected Lettuce and Salsa, got %v", toppings)
+	}
+}
+
+func TestDriverKeyMap(t *testing.T) {
+	keymap := huh.NewDefaultKeyMap()
+	keymap.Review.Submit = key.NewBinding(key.WithKeys("ctrl+s"))
+	form := huh.NewForm(huh.NewGroup(huh.NewInput().Key("name"))).WithReview(true)
+
+	d := New(t, form).WithKeyMap(keymap)
+	d.Type("Glenn").Submit()
+	if !form.Reviewing() {
+		t.Fatal("expected the review page to be shown")
+	}
+	d.Submit()
+	if form.State != huh.StateCompleted {
+		t.Errorf("expected the form to be submitted with the review key, got %v", form.State)
+	}
+}
@@ -18,6 +18,34 @@ func (s *Selector[T]) Append(item T) {
 	s.items = append(s.items, item)
 }
 
+// Insert inserts an item at the given index, keeping the selected item
+// selected.
+func (s *Selector[T]) Insert(i int, item T) {
+	i = max(0, min(i, len(s.items)))
+	// Use a new slice, so that ranges in progress go on with the old items.
+	items := make([]T, 0, len(s.items)+1)
+	items = append(items, s.items[:i]...)
+	items = append(items, item)
+	s.items = append(items, s.items[i:]...)
+	if i <= s.index && len(s.items) > 1 {
+		s.index++
+	}
+}
+
+// Remove removes the item at the given index. The selected item stays
+// selected, unless it is the one removed, in which case the item after it is
+// selected, or the one before it if it was the last.
+func (s *Selector[T]) Remove(i int) {
+	if i < 0 || i >= len(s.items) {
+		return
+	}
+	// Use a new slice, so that ranges in progress go on with the old items.
+	s.items = append(s.items[:i:i], s.items[i+1:]...)
+	if i < s.index || s.index == len(s.items) {
+		s.index = max(0, s.index-1)
+	}
+}
+
 // Next moves the selector to the next item.
 func (s *Selector[T]) Next() {
 	if s.index < len(s.items)-1 {
@@ -7,12 +7,19 @@ type KeyMap struct {
 	Quit key.Binding
 
 	Confirm     ConfirmKeyMap
+	DatePicker  DatePickerKeyMap
 	FilePicker  FilePickerKeyMap
 	Input       InputKeyMap
+	KeyValue    KeyValueKeyMap
+	List        ListKeyMap
 	MultiSelect MultiSelectKeyMap
 	Note        NoteKeyMap
+	Number      NumberKeyMap
+	OrderedList OrderedListKeyMap
+	Review      ReviewKeyMap
 	Select      SelectKeyMap
 	Text        TextKeyMap
+	TreeSelect  TreeSelectKeyMap
 }
 
 // InputKeyMap is the keybindings for input fields.
@@ -23,6 +30,37 @@ type InputKeyMap struct {
 	Submit           key.Binding
 }
 
+// KeyValueKeyMap is the keybindings for key-value fields. Keys and values are
+// typed in with the keybindings of input fields.
+type KeyValueKeyMap struct {
+	Add    key.Binding
+	Remove key.Binding
+	Next   key.Binding
+	Prev   key.Binding
+	Submit key.Binding
+}
+
+// ListKeyMap is the keybindings for list fields. Moving through the fields of
+// the entries uses the keybindings of those fields.
+type ListKeyMap struct {
+	Add      key.Binding
+	Remove   key.Binding
+	MoveUp   key.Binding
+	MoveDown key.Binding
+	Next     key.Binding
+	Prev     key.Binding
+	Submit   key.Binding
+}
+
+// NumberKeyMap is the keybindings for number fields.
+type NumberKeyMap struct {
+	Increment key.Binding
+	Decrement key.Binding
+	Next      key.Binding
+	Prev      key.Binding
+	Submit    key.Binding
+}
+
 // TextKeyMap is the keybindings for text fields.
 type TextKeyMap struct {
 	Next    key.Binding
@@ -48,6 +86,27 @@ type SelectKeyMap struct {
 	SetFilter    key.Binding
 	ClearFilter  key.Binding
 	Submit       key.Binding
+	PreviewUp    key.Binding
+	PreviewDown  key.Binding
+}
+
+// TreeSelectKeyMap is the keybindings for tree select fields.
+type TreeSelectKeyMap struct {
+	Next         key.Binding
+	Prev         key.Binding
+	Up           key.Binding
+	Down         key.Binding
+	HalfPageUp   key.Binding
+	HalfPageDown key.Binding
+	GotoTop      key.Binding
+	GotoBottom   key.Binding
+	Expand       key.Binding
+	Collapse     key.Binding
+	Toggle       key.Binding
+	Filter       key.Binding
+	SetFilter    key.Binding
+	ClearFilter  key.Binding
+	Submit       key.Binding
 }
 
 // MultiSelectKeyMap is the keybindings for multi-select fields.
@@ -67,6 +126,21 @@ type MultiSelectKeyMap struct {
 	Submit       key.Binding
 	SelectAll    key.Binding
 	SelectNone   key.Binding
+	PreviewUp    key.Binding
+	PreviewDown  key.Binding
+}
+
+// OrderedListKeyMap is the keybindings for ordered list fields.
+type OrderedListKeyMap struct {
+	Next       key.Binding
+	Prev       key.Binding
+	Up         key.Binding
+	Down       key.Binding
+	MoveUp     key.Binding
+	MoveDown   key.Binding
+	GotoTop    key.Binding
+	GotoBottom key.Binding
+	Submit     key.Binding
 }
 
 // FilePickerKey is the keybindings for filepicker fields.
@@ -86,6 +160,21 @@ type FilePickerKeyMap struct {
 	Submit   key.Binding
 }
 
+// DatePickerKeyMap is the keybindings for date picker fields.
+type DatePickerKeyMap struct {
+	Left      key.Binding
+	Right     key.Binding
+	Up        key.Binding
+	Down      key.Binding
+	PrevMonth key.Binding
+	NextMonth key.Binding
+	Today     key.Binding
+	Time      key.Binding
+	Next      key.Binding
+	Prev      key.Binding
+	Submit    key.Binding
+}
+
 // NoteKeyMap is the keybindings for note fields.
 type NoteKeyMap struct {
 	Next   key.Binding
@@ -103,6 +192,15 @@ type ConfirmKeyMap struct {
 	Reject key.Binding
 }
 
+// ReviewKeyMap is the keybindings for the review page of a form.
+type ReviewKeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Edit   key.Binding
+	Prev   key.Binding
+	Submit key.Binding
+}
+
 // NewDefaultKeyMap returns a new default keymap.
 func NewDefaultKeyMap() *KeyMap {
 	return &KeyMap{
@@ -113,6 +211,29 @@ func NewDefaultKeyMap() *KeyMap {
 			Next:             key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter", "next")),
 			Submit:           key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
 		},
+		KeyValue: KeyValueKeyMap{
+			Add:    key.NewBinding(key.WithKeys("alt+n"), key.WithHelp("alt+n", "add")),
+			Remove: key.NewBinding(key.WithKeys("alt+x"), key.WithHelp("alt+x", "remove")),
+			Prev:   key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
+			Next:   key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter", "next")),
+			Submit: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
+		},
+		List: ListKeyMap{
+			Add:      key.NewBinding(key.WithKeys("alt+n"), key.WithHelp("alt+n", "add")),
+			Remove:   key.NewBinding(key.WithKeys("alt+x"), key.WithHelp("alt+x", "remove")),
+			MoveUp:   key.NewBinding(key.WithKeys("alt+up"), key.WithHelp("alt+↑", "move up")),
+			MoveDown: key.NewBinding(key.WithKeys("alt+down"), key.WithHelp("alt+↓", "move down")),
+			Prev:     key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
+			Next:     key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter", "next")),
+			Submit:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
+		},
+		Number: NumberKeyMap{
+			Increment: key.NewBinding(key.WithKeys("up", "ctrl+p"), key.WithHelp("↑", "increment")),
+			Decrement: key.NewBinding(key.WithKeys("down", "ctrl+n"), key.WithHelp("↓", "decrement")),
+			Prev:      key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
+			Next:      key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter", "next")),
+			Submit:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
+		},
 		FilePicker: FilePickerKeyMap{
 			GoToTop:  key.NewBinding(key.WithKeys("g"), key.WithHelp("g", "first"), key.WithDisabled()),
 			GoToLast: key.NewBinding(key.WithKeys("G"), key.WithHelp("G", "last"), key.WithDisabled()),
@@ -151,6 +272,25 @@ func NewDefaultKeyMap() *KeyMap {
 			HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "½ page down")),
 			GotoTop:      key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("g/home", "go to start")),
 			GotoBottom:   key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G/end", "go to end")),
+			PreviewUp:    key.NewBinding(key.WithKeys("shift+up"), key.WithHelp("shift+↑", "scroll preview up"), key.WithDisabled()),
+			PreviewDown:  key.NewBinding(key.WithKeys("shift+down"), key.WithHelp("shift+↓", "scroll preview down"), key.WithDisabled()),
+		},
+		TreeSelect: TreeSelectKeyMap{
+			Prev:         key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
+			Next:         key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter", "select")),
+			Submit:       key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
+			Up:           key.NewBinding(key.WithKeys("up", "k", "ctrl+k", "ctrl+p"), key.WithHelp("↑", "up")),
+			Down:         key.NewBinding(key.WithKeys("down", "j", "ctrl+j", "ctrl+n"), key.WithHelp("↓", "down")),
+			Expand:       key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→", "expand")),
+			Collapse:     key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←", "collapse")),
+			Toggle:       key.NewBinding(key.WithKeys(" ", "x"), key.WithHelp("x/space", "select"), key.WithDisabled()),
+			Filter:       key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+			SetFilter:    key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "set filter"), key.WithDisabled()),
+			ClearFilter:  key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "clear filter"), key.WithDisabled()),
+			HalfPageUp:   key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "½ page up")),
+			HalfPageDown: key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "½ page down")),
+			GotoTop:      key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("g/home", "go to start")),
+			GotoBottom:   key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G/end", "go to end")),
 		},
 		MultiSelect: MultiSelectKeyMap{
 			Prev:         key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
@@ -168,6 +308,32 @@ func NewDefaultKeyMap() *KeyMap {
 			GotoBottom:   key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G/end", "go to end")),
 			SelectAll:    key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "select all")),
 			SelectNone:   key.NewBinding(key.WithKeys("ctrl+a"), key.WithHelp("ctrl+a", "select none"), key.WithDisabled()),
+			PreviewUp:    key.NewBinding(key.WithKeys("shift+up"), key.WithHelp("shift+↑", "scroll preview up"), key.WithDisabled()),
+			PreviewDown:  key.NewBinding(key.WithKeys("shift+down"), key.WithHelp("shift+↓", "scroll preview down"), key.WithDisabled()),
+		},
+		OrderedList: OrderedListKeyMap{
+			Prev:       key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
+			Next:       key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter", "confirm")),
+			Submit:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
+			Up:         key.NewBinding(key.WithKeys("up", "k", "ctrl+p"), key.WithHelp("↑", "up")),
+			Down:       key.NewBinding(key.WithKeys("down", "j", "ctrl+n"), key.WithHelp("↓", "down")),
+			MoveUp:     key.NewBinding(key.WithKeys("shift+up", "K"), key.WithHelp("shift+↑", "move up")),
+			MoveDown:   key.NewBinding(key.WithKeys("shift+down", "J"), key.WithHelp("shift+↓", "move down")),
+			GotoTop:    key.NewBinding(key.WithKeys("home", "g"), key.WithHelp("g/home", "go to start")),
+			GotoBottom: key.NewBinding(key.WithKeys("end", "G"), key.WithHelp("G/end", "go to end")),
+		},
+		DatePicker: DatePickerKeyMap{
+			Left:      key.NewBinding(key.WithKeys("left", "h"), key.WithHelp("←", "previous day")),
+			Right:     key.NewBinding(key.WithKeys("right", "l"), key.WithHelp("→", "next day")),
+			Up:        key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑", "previous week")),
+			Down:      key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓", "next week")),
+			PrevMonth: key.NewBinding(key.WithKeys("pgup", "["), key.WithHelp("[", "previous month")),
+			NextMonth: key.NewBinding(key.WithKeys("pgdown", "]"), key.WithHelp("]", "next month")),
+			Today:     key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "today")),
+			Time:      key.NewBinding(key.WithKeys(":"), key.WithHelp(":", "edit time"), key.WithDisabled()),
+			Prev:      key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
+			Next:      key.NewBinding(key.WithKeys("enter", "tab"), key.WithHelp("enter", "next")),
+			Submit:    key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "submit")),
 		},
 		Note: NoteKeyMap{
 			Prev:   key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
@@ -182,5 +348,12 @@ func NewDefaultKeyMap() *KeyMap {
 			Accept: key.NewBinding(key.WithKeys("y", "Y"), key.WithHelp("y", "Yes")),
 			Reject: key.NewBinding(key.WithKeys("n", "N"), key.WithHelp("n", "No")),
 		},
+		Review: ReviewKeyMap{
+			Up:     key.NewBinding(key.WithKeys("up", "k", "ctrl+p"), key.WithHelp("↑", "up")),
+			Down:   key.NewBinding(key.WithKeys("down", "j", "ctrl+n"), key.WithHelp("↓", "down")),
+			Edit:   key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "edit")),
+			Prev:   key.NewBinding(key.WithKeys("shift+tab"), key.WithHelp("shift+tab", "back")),
+			Submit: key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "submit")),
+		},
 	}
 }
@@ -56,9 +56,8 @@ func (l *layoutColumns) visibleGroups(f *Form) []*Group {
 	f.selector.Range(func(i int, group *Group) bool {
 		if i >= start && i < end {
 			groups = append(groups, group)
-			return true
 		}
-		return false
+		return i < end
 	})
 
 	return groups
@@ -124,9 +123,8 @@ func (l *layoutGrid) visibleGroups(f *Form) [][]*Group {
 	f.selector.Range(func(i int, group *Group) bool {
 		if i >= start && i < end {
 			visible = append(visible, group)
-			return true
 		}
-		return false
+		return i < end
 	})
 	grid := make([][]*Group, l.rows)
 	for i := 0; i < l.rows; i++ {
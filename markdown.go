@@ -0,0 +1,792 @@
+package huh
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// defaultRuleWidth is the width of a thematic break when there is no width to
+// fill.
+const defaultRuleWidth = 20
+
+// markdownRenderer renders a subset of CommonMark, along with tables and
+// strikethrough, to styled text wrapped to a width.
+//
+// Headings, paragraphs, lists, block quotes, fenced code, thematic breaks and
+// tables are supported, as well as emphasis, code spans, links and autolinks
+// in text. Other HTML and reference links are shown as they are.
+type markdownRenderer struct {
+	styles *MarkdownStyles
+	text   lipgloss.Style
+
+	// plain renders plain text for accessible mode, with the same markers
+	// as in Markdown.
+	plain bool
+}
+
+// renderMarkdown renders Markdown with the given styles, wrapped to width. Text
+// is rendered with the text style. Nothing is wrapped if width is 0.
+func renderMarkdown(input string, width int, styles *MarkdownStyles, text lipgloss.Style) string {
+	r := markdownRenderer{styles: styles, text: text}
+	return r.render(input, width)
+}
+
+// plainMarkdown renders Markdown as plain text, without markup or styles.
+func plainMarkdown(input string) string {
+	r := markdownRenderer{styles: &MarkdownStyles{}, plain: true}
+	return r.render(input, 0)
+}
+
+// markdownDescription renders the description of a field of the given width,
+// as Markdown when the theme renders descriptions as Markdown.
+func markdownDescription(theme *Theme, styles *FieldStyles, description string, width int) string {
+	if theme == nil || !theme.MarkdownDescriptions {
+		return styles.Description.Render(description)
+	}
+	if width > 0 {
+		width = max(width-styles.Base.GetHorizontalFrameSize(), 1)
+	}
+	return renderMarkdown(description, width, &styles.Markdown, styles.Description)
+}
+
+// plainDescription returns the description of a field for accessible mode,
+// without markup when the theme renders descriptions as Markdown.
+func plainDescription(theme *Theme, description string) string {
+	if theme == nil || !theme.MarkdownDescriptions {
+		return description
+	}
+	return plainMarkdown(description)
+}
+
+func (r *markdownRenderer) render(input string, width int) string {
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	input = strings.ReplaceAll(input, "\t", "    ")
+	return strings.Join(r.blocks(strings.Split(input, "\n"), width), "\n\n")
+}
+
+// blocks renders the blocks of the given lines, each block being returned on
+// its own.
+func (r *markdownRenderer) blocks(lines []string, width int) []string {
+	var blocks []string
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			i++
+		case fenceOf(line) != "":
+			var n int
+			n, line = r.codeBlock(lines[i:], width)
+			blocks = append(blocks, line)
+			i += n
+		case headingLevel(line) > 0:
+			blocks = append(blocks, r.heading(headingLevel(line), headingText(line), width))
+			i++
+		case isRule(line):
+			blocks = append(blocks, r.rule(width))
+			i++
+		case strings.HasPrefix(trimmed, ">"):
+			var n int
+			n, line = r.blockQuote(lines[i:], width)
+			blocks = append(blocks, line)
+			i += n
+		case isListItem(line):
+			var n int
+			n, line = r.list(lines[i:], width)
+			blocks = append(blocks, line)
+			i += n
+		case i+1 < len(lines) && isTableRow(line) && isTableDelimiter(lines[i+1]):
+			var n int
+			n, line = r.table(lines[i:], width)
+			blocks = append(blocks, line)
+			i += n
+		default:
+			var n int
+			n, line = r.paragraph(lines[i:], width)
+			blocks = append(blocks, line)
+			i += n
+		}
+	}
+	return blocks
+}
+
+// interrupts returns whether a line starts a block that ends a paragraph.
+func interrupts(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return trimmed == "" || fenceOf(line) != "" || headingLevel(line) > 0 ||
+		isRule(line) || strings.HasPrefix(trimmed, ">") || isListItem(line)
+}
+
+// paragraph renders the paragraph at the start of lines, and returns the
+// number of lines it spans. A paragraph followed by a line of = or - is a
+// heading.
+func (r *markdownRenderer) paragraph(lines []string, width int) (int, string) {
+	var sb strings.Builder
+	n := 0
+	for n < len(lines) {
+		if n > 0 {
+			if level := setextLevel(lines[n]); level > 0 {
+				return n + 1, r.heading(level, strings.TrimSpace(sb.String()), width)
+			}
+			if interrupts(lines[n]) {
+				break
+			}
+		}
+		line := strings.TrimLeft(lines[n], " ")
+		n++
+		switch {
+		case n == len(lines) || interrupts(lines[n]):
+			sb.WriteString(strings.TrimRight(line, " "))
+		case strings.HasSuffix(line, "  "):
+			sb.WriteString(strings.TrimRight(line, " ") + "\n")
+		case strings.HasSuffix(line, "\\"):
+			sb.WriteString(strings.TrimSuffix(line, "\\") + "\n")
+		default:
+			sb.WriteString(strings.TrimRight(line, " ") + " ")
+		}
+	}
+	return n, wrap(r.inline(sb.String(), r.text), width)
+}
+
+// heading renders a heading of the given level.
+func (r *markdownRenderer) heading(level int, text string, width int) string {
+	style := r.styles.Heading
+	if level > 2 {
+		style = r.styles.Subheading
+	}
+	return wrap(r.inline(text, style.Inherit(r.text)), width)
+}
+
+// rule renders a thematic break filling the width.
+func (r *markdownRenderer) rule(width int) string {
+	if width <= 0 {
+		width = defaultRuleWidth
+	}
+	if r.plain {
+		return "---"
+	}
+	char := r.styles.Rule.Value()
+	if char == "" {
+		char = "─"
+	}
+	return r.styles.Rule.UnsetString().Render(strings.Repeat(char, width/ansi.StringWidth(char)))
+}
+
+// codeBlock renders the fenced code block at the start of lines, and returns
+// the number of lines it spans. Long lines are broken at the width.
+func (r *markdownRenderer) codeBlock(lines []string, width int) (int, string) {
+	fence := fenceOf(lines[0])
+	indent := len(lines[0]) - len(strings.TrimLeft(lines[0], " "))
+	style := r.styles.CodeBlock
+	width -= style.GetHorizontalFrameSize()
+
+	var code []string
+	n := 1
+	for ; n < len(lines); n++ {
+		if trimmed := strings.TrimSpace(lines[n]); strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			n++
+			break
+		}
+		line := lines[n]
+		for i := 0; i < indent && strings.HasPrefix(line, " "); i++ {
+			line = line[1:]
+		}
+		if width > 0 {
+			line = ansi.Hardwrap(line, width, true)
+		}
+		code = append(code, strings.Split(line, "\n")...)
+	}
+
+	for i, line := range code {
+		code[i] = style.Render(line)
+	}
+	return n, strings.Join(code, "\n")
+}
+
+// blockQuote renders the block quote at the start of lines, and returns the
+// number of lines it spans.
+func (r *markdownRenderer) blockQuote(lines []string, width int) (int, string) {
+	var inner []string
+	n := 0
+	for ; n < len(lines); n++ {
+		trimmed := strings.TrimSpace(lines[n])
+		if !strings.HasPrefix(trimmed, ">") {
+			// Lines continuing a paragraph belong to the quote too.
+			if trimmed == "" || interrupts(lines[n]) || len(inner) == 0 || strings.TrimSpace(inner[len(inner)-1]) == "" {
+				break
+			}
+			inner = append(inner, trimmed)
+			continue
+		}
+		trimmed = strings.TrimPrefix(trimmed, ">")
+		inner = append(inner, strings.TrimPrefix(trimmed, " "))
+	}
+
+	marker := r.styles.BlockQuote.String()
+	if r.plain {
+		marker = "> "
+	}
+	if width > 0 {
+		width = max(width-ansi.StringWidth(marker), 1)
+	}
+	quoted := strings.Split(strings.Join(r.blocks(inner, width), "\n\n"), "\n")
+	for i, line := range quoted {
+		quoted[i] = marker + line
+	}
+	return n, strings.Join(quoted, "\n")
+}
+
+// listItem is an item of a list.
+type listItem struct {
+	indent  int
+	ordered bool
+	number  int
+	text    string
+}
+
+// list renders the list at the start of lines, and returns the number of
+// lines it spans. Items indented under others are nested.
+func (r *markdownRenderer) list(lines []string, width int) (int, string) {
+	var items []listItem
+	n := 0
+	for ; n < len(lines); n++ {
+		line := lines[n]
+		if strings.TrimSpace(line) == "" {
+			// A blank line ends the list unless another item or an
+			// indented line follows.
+			if n+1 < len(lines) && (isListItem(lines[n+1]) || strings.HasPrefix(lines[n+1], "  ")) {
+				continue
+			}
+			break
+		}
+		if item, ok := parseListItem(line); ok {
+			// A list of another kind starts a new list.
+			if len(items) > 0 && item.indent <= items[0].indent && item.ordered != items[0].ordered {
+				break
+			}
+			items = append(items, item)
+			continue
+		}
+		if fenceOf(line) != "" || headingLevel(line) > 0 || isRule(line) {
+			break
+		}
+		last := &items[len(items)-1]
+		last.text += " " + strings.TrimSpace(line)
+	}
+
+	var (
+		out     []string
+		indents []int
+		numbers []int
+		ordered []bool
+	)
+	for _, item := range items {
+		for len(indents) > 0 && indents[len(indents)-1] > item.indent {
+			indents = indents[:len(indents)-1]
+			numbers = numbers[:len(numbers)-1]
+			ordered = ordered[:len(ordered)-1]
+		}
+		top := len(indents) - 1
+		switch {
+		case top < 0 || indents[top] < item.indent:
+			indents = append(indents, item.indent)
+			numbers = append(numbers, item.number)
+			ordered = append(ordered, item.ordered)
+		case ordered[top] != item.ordered:
+			// A nested list of another kind starts numbering again.
+			numbers[top], ordered[top] = item.number, item.ordered
+		default:
+			numbers[top]++
+		}
+		depth := len(indents) - 1
+
+		marker := r.styles.Bullet.String()
+		if r.plain || marker == "" {
+			marker = "-"
+		}
+		if item.ordered {
+			marker = r.styles.Bullet.UnsetString().Render(strconv.Itoa(numbers[depth]) + ".")
+		}
+		marker += " "
+
+		indent := strings.Repeat(" ", depth*2)
+		hang := indent + strings.Repeat(" ", ansi.StringWidth(marker))
+		textWidth := width
+		if width > 0 {
+			textWidth = max(width-ansi.StringWidth(hang), 1)
+		}
+		for i, line := range strings.Split(wrap(r.inline(item.text, r.text), textWidth), "\n") {
+			if i == 0 {
+				out = append(out, indent+marker+line)
+			} else {
+				out = append(out, hang+line)
+			}
+		}
+	}
+	return n, strings.Join(out, "\n")
+}
+
+// table renders the table at the start of lines, and returns the number of
+// lines it spans. Rows wider than the width are cut off.
+func (r *markdownRenderer) table(lines []string, width int) (int, string) {
+	header := tableCells(lines[0])
+	var aligns []lipgloss.Position
+	for _, cell := range tableCells(lines[1]) {
+		switch {
+		case strings.HasPrefix(cell, ":") && strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, lipgloss.Center)
+		case strings.HasSuffix(cell, ":"):
+			aligns = append(aligns, lipgloss.Right)
+		default:
+			aligns = append(aligns, lipgloss.Left)
+		}
+	}
+
+	rows := [][]string{r.tableRow(header, r.styles.TableHeader.Inherit(r.text))}
+	n := 2
+	for ; n < len(lines) && isTableRow(lines[n]) && strings.TrimSpace(lines[n]) != ""; n++ {
+		rows = append(rows, r.tableRow(tableCells(lines[n]), r.text))
+	}
+
+	widths := make([]int, len(aligns))
+	for _, row := range rows {
+		for i := range widths {
+			if i < len(row) {
+				widths[i] = max(widths[i], ansi.StringWidth(row[i]))
+			}
+		}
+	}
+
+	sep, cross, line := " │ ", "─┼─", "─"
+	if r.plain {
+		sep, cross, line = " | ", "-|-", "-"
+	}
+	border := r.styles.TableBorder
+	var out []string
+	for i, row := range rows {
+		cells := make([]string, len(widths))
+		for j, w := range widths {
+			var cell string
+			if j < len(row) {
+				cell = row[j]
+			}
+			cells[j] = lipgloss.PlaceHorizontal(w, aligns[j], cell)
+		}
+		out = append(out, strings.Join(cells, border.Render(sep)))
+		if i == 0 {
+			rules := make([]string, len(widths))
+			for j, w := range widths {
+				rules[j] = strings.Repeat(line, w)
+			}
+			out = append(out, border.Render(strings.Join(rules, cross)))
+		}
+	}
+	if width > 0 {
+		for i := range out {
+			out[i] = ansi.Truncate(out[i], width, "…")
+		}
+	}
+	return n, strings.Join(out, "\n")
+}
+
+// tableRow renders the cells of a row of a table.
+func (r *markdownRenderer) tableRow(cells []string, style lipgloss.Style) []string {
+	row := make([]string, len(cells))
+	for i, cell := range cells {
+		row[i] = r.inline(cell, style)
+	}
+	return row
+}
+
+// inline renders the text of a block, with emphasis, code spans and links,
+// in the given style.
+func (r *markdownRenderer) inline(s string, style lipgloss.Style) string {
+	var (
+		out  strings.Builder
+		text strings.Builder
+	)
+	flush := func() {
+		out.WriteString(r.styled(text.String(), style))
+		text.Reset()
+	}
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			run := runLength(s, i, '`')
+			if end := findRun(s, i+run, '`', run); end >= 0 {
+				flush()
+				code := strings.ReplaceAll(s[i+run:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				out.WriteString(r.styles.Code.Render(code))
+				i = end + run
+				continue
+			}
+			text.WriteString(s[i : i+run])
+			i += run
+			continue
+
+		case c == '*' || c == '_' || (c == '~' && strings.HasPrefix(s[i:], "~~")):
+			run := runLength(s, i, c)
+			if end, n := r.findEmphasis(s, i, run); end >= 0 {
+				flush()
+				inner := style
+				switch {
+				case c == '~':
+					inner = r.styles.Strikethrough.Inherit(inner)
+				case n == 1:
+					inner = r.styles.Emphasis.Inherit(inner)
+				case n == 2:
+					inner = r.styles.Strong.Inherit(inner)
+				default:
+					inner = r.styles.Strong.Inherit(r.styles.Emphasis).Inherit(inner)
+				}
+				text.WriteString(s[i : i+run-n])
+				flush()
+				out.WriteString(r.inline(s[i+run:end], inner))
+				i = end + n
+				continue
+			}
+			text.WriteString(s[i : i+run])
+			i += run
+			continue
+
+		case c == '[' || (c == '!' && strings.HasPrefix(s[i:], "![")):
+			start := i
+			if c == '!' {
+				start++
+			}
+			if label, url, end, ok := parseLink(s, start); ok {
+				flush()
+				out.WriteString(r.link(label, url))
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if end := strings.IndexByte(s[i:], '>'); end > 0 {
+				if url := s[i+1 : i+end]; isAutolink(url) {
+					flush()
+					out.WriteString(r.link(url, url))
+					i += end + 1
+					continue
+				}
+			}
+		}
+		text.WriteByte(c)
+		i++
+	}
+	flush()
+	return out.String()
+}
+
+// findEmphasis returns the index of the delimiters closing the emphasis
+// opened by the run of delimiters at i, and how many of them it uses, or -1
+// if it isn't closed.
+func (r *markdownRenderer) findEmphasis(s string, i, run int) (int, int) {
+	c := s[i]
+	after := i + run
+	if after >= len(s) || s[after] == ' ' || s[after] == '\n' {
+		return -1, 0
+	}
+	// Underscores within words, as in snake_case, are not emphasis.
+	if c == '_' && i > 0 && isWordChar(s[i-1]) {
+		return -1, 0
+	}
+	n := min(run, 3)
+	if c == '~' {
+		if run != 2 {
+			return -1, 0
+		}
+		n = 2
+	}
+	for j := after; j < len(s); j++ {
+		if s[j] == '`' {
+			// Skip code spans, which may contain delimiters.
+			cr := runLength(s, j, '`')
+			if end := findRun(s, j+cr, '`', cr); end >= 0 {
+				j = end + cr - 1
+			}
+			continue
+		}
+		if s[j] != c {
+			continue
+		}
+		closing := runLength(s, j, c)
+		if s[j-1] != ' ' && s[j-1] != '\n' && closing >= n &&
+			(c != '_' || j+closing >= len(s) || !isWordChar(s[j+closing])) {
+			return j + closing - n, n
+		}
+		j += closing - 1
+	}
+	return -1, 0
+}
+
+// link renders a link with its URL, unless the label is the URL.
+func (r *markdownRenderer) link(label, url string) string {
+	text := r.inline(label, r.styles.Link.Inherit(r.text))
+	if url == "" || ansi.Strip(text) == url {
+		return text
+	}
+	return text + r.styled(" ("+url+")", r.styles.LinkURL.Inherit(r.text))
+}
+
+// styled renders text in a style word by word, so that wrapping doesn't
+// carry styles over to the next line.
+func (r *markdownRenderer) styled(text string, style lipgloss.Style) string {
+	if text == "" {
+		return ""
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		words := strings.Split(line, " ")
+		for j, word := range words {
+			if word != "" {
+				words[j] = style.Render(word)
+			}
+		}
+		lines[i] = strings.Join(words, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wrap wraps text to the width, breaking words longer than it.
+func wrap(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return ansi.Wrap(s, width, "")
+}
+
+// fenceOf returns the fence opening a code block on the line, or an empty
+// string.
+func fenceOf(line string) string {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return ""
+	}
+	for _, c := range []byte{'`', '~'} {
+		if run := runLength(trimmed, 0, c); run >= 3 {
+			if c == '`' && strings.ContainsRune(trimmed[run:], '`') {
+				return ""
+			}
+			return trimmed[:run]
+		}
+	}
+	return ""
+}
+
+// headingLevel returns the level of the ATX heading on the line, or 0.
+func headingLevel(line string) int {
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return 0
+	}
+	level := runLength(trimmed, 0, '#')
+	if level == 0 || level > 6 || (len(trimmed) > level && trimmed[level] != ' ') {
+		return 0
+	}
+	return level
+}
+
+// headingText returns the text of the ATX heading on the line, without the
+// closing sequence of #.
+func headingText(line string) string {
+	text := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "#"))
+	if trimmed := strings.TrimRight(text, "#"); trimmed == "" || strings.HasSuffix(trimmed, " ") {
+		text = strings.TrimSpace(trimmed)
+	}
+	return text
+}
+
+// setextLevel returns the level of the heading underlined by the line, or 0.
+func setextLevel(line string) int {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return 0
+	case strings.Trim(trimmed, "=") == "":
+		return 1
+	case strings.Trim(trimmed, "-") == "":
+		return 2
+	}
+	return 0
+}
+
+// isRule returns whether the line is a thematic break, three or more of the
+// same of -, * or _.
+func isRule(line string) bool {
+	trimmed := strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+	if len(trimmed) < 3 || len(line)-len(strings.TrimLeft(line, " ")) > 3 {
+		return false
+	}
+	return strings.Trim(trimmed, trimmed[:1]) == "" && strings.ContainsAny(trimmed[:1], "-*_")
+}
+
+// isListItem returns whether the line starts an item of a list.
+func isListItem(line string) bool {
+	_, ok := parseListItem(line)
+	return ok && !isRule(line)
+}
+
+// parseListItem parses the item of a list starting on the line.
+func parseListItem(line string) (listItem, bool) {
+	trimmed := strings.TrimLeft(line, " ")
+	item := listItem{indent: len(line) - len(trimmed)}
+	if len(trimmed) >= 2 && strings.ContainsRune("-*+", rune(trimmed[0])) && trimmed[1] == ' ' {
+		item.text = strings.TrimSpace(trimmed[2:])
+		return item, true
+	}
+	digits := 0
+	for digits < len(trimmed) && digits < 9 && trimmed[digits] >= '0' && trimmed[digits] <= '9' {
+		digits++
+	}
+	if digits == 0 || digits+1 >= len(trimmed) || (trimmed[digits] != '.' && trimmed[digits] != ')') || trimmed[digits+1] != ' ' {
+		return item, false
+	}
+	item.ordered = true
+	item.number, _ = strconv.Atoi(trimmed[:digits])
+	item.text = strings.TrimSpace(trimmed[digits+2:])
+	return item, true
+}
+
+// isTableRow returns whether the line may be a row of a table.
+func isTableRow(line string) bool {
+	return strings.Contains(line, "|")
+}
+
+// isTableDelimiter returns whether the line is the row delimiting the header
+// of a table, such as |---|:-:|.
+func isTableDelimiter(line string) bool {
+	cells := tableCells(line)
+	if len(cells) == 0 || !isTableRow(line) {
+		return false
+	}
+	for _, cell := range cells {
+		if strings.Trim(cell, ":") == "" || strings.Trim(cell, "-:") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// tableCells splits a row of a table into its cells.
+func tableCells(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if !strings.HasSuffix(line, "\\|") {
+		line = strings.TrimSuffix(line, "|")
+	}
+	var (
+		cells []string
+		cell  strings.Builder
+	)
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseLink parses the link starting with the [ at i, returning its label,
+// its URL and the index after it.
+func parseLink(s string, i int) (string, string, int, bool) {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth > 0 {
+				continue
+			}
+			if j+1 >= len(s) || s[j+1] != '(' {
+				return "", "", 0, false
+			}
+			end := strings.IndexByte(s[j+1:], ')')
+			if end < 0 {
+				return "", "", 0, false
+			}
+			dest := strings.TrimSpace(s[j+2 : j+1+end])
+			// Leave out the title of the link.
+			if k := strings.IndexAny(dest, " \t"); k >= 0 {
+				dest = dest[:k]
+			}
+			dest = strings.TrimSuffix(strings.TrimPrefix(dest, "<"), ">")
+			return s[i+1 : j], dest, j + 2 + end, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// isAutolink returns whether the text between < and > is a URL or an email
+// address.
+func isAutolink(s string) bool {
+	if strings.ContainsAny(s, " <>") || s == "" {
+		return false
+	}
+	if scheme, _, ok := strings.Cut(s, ":"); ok && len(scheme) >= 2 {
+		return true
+	}
+	at := strings.IndexByte(s, '@')
+	return at > 0 && strings.Contains(s[at:], ".")
+}
+
+// runLength returns the number of c at the start of s[i:].
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// findRun returns the index of the next run of exactly n of c from i, or -1.
+func findRun(s string, i int, c byte, n int) int {
+	for i < len(s) {
+		j := strings.IndexByte(s[i:], c)
+		if j < 0 {
+			return -1
+		}
+		j += i
+		run := runLength(s, j, c)
+		if run == n {
+			return j
+		}
+		i = j + run
+	}
+	return -1
+}
+
+// isASCIIPunct returns whether c can be escaped with a backslash.
+func isASCIIPunct(c byte) bool {
+	return c < unicode.MaxASCII && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+// isWordChar returns whether c is part of a word.
+func isWordChar(c byte) bool {
+	return c >= 0x80 || c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
@@ -1,14 +1,37 @@
 package huh
 
-import "fmt"
+import (
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Option is an option for select fields.
+//
+// Besides choices, options can be group headers and separators, which are
+// shown in the list but can't be selected, see NewOptionHeader and
+// NewOptionSeparator.
 type Option[T comparable] struct {
 	Key      string
 	Value    T
 	selected bool
+
+	kind        optionKind
+	description string
+	disabled    bool
+	reason      string
 }
 
+// optionKind is the kind of row an option is shown as.
+type optionKind int
+
+const (
+	optionChoice optionKind = iota
+	optionHeader
+	optionSeparator
+)
+
 // NewOptions returns new options from a list of values.
 func NewOptions[T comparable](values ...T) []Option[T] {
 	options := make([]Option[T], len(values))
@@ -26,13 +49,183 @@ func NewOption[T comparable](key string, value T) Option[T] {
 	return Option[T]{Key: key, Value: value}
 }
 
+// NewOptionHeader returns the header of a group of options, such as
+// "Fruits", shown above the options following it. While filtering, headers
+// are kept above their matching options.
+func NewOptionHeader[T comparable](title string) Option[T] {
+	return Option[T]{Key: title, kind: optionHeader}
+}
+
+// NewOptionSeparator returns a line separating options. Separators are
+// hidden while filtering.
+func NewOptionSeparator[T comparable]() Option[T] {
+	return Option[T]{kind: optionSeparator}
+}
+
 // Selected sets whether the option is currently selected.
 func (o Option[T]) Selected(selected bool) Option[T] {
 	o.selected = selected
 	return o
 }
 
+// Description sets a secondary description shown dimmed next to the key of
+// the option.
+func (o Option[T]) Description(description string) Option[T] {
+	o.description = description
+	return o
+}
+
+// Disabled disables the option, which is shown but can't be selected, with
+// the reason shown next to its key. The reason may be empty.
+func (o Option[T]) Disabled(reason string) Option[T] {
+	o.disabled = true
+	o.reason = reason
+	return o
+}
+
 // String returns the key of the option.
 func (o Option[T]) String() string {
 	return o.Key
 }
+
+// isChoice returns whether the option is a choice rather than a header or a
+// separator.
+func (o Option[T]) isChoice() bool {
+	return o.kind == optionChoice
+}
+
+// selectable returns whether the option is a choice that is not disabled.
+func (o Option[T]) selectable() bool {
+	return o.isChoice() && !o.disabled
+}
+
+// details returns the description of the option, followed by the reason it
+// is disabled.
+func (o Option[T]) details() string {
+	switch {
+	case o.reason == "":
+		return o.description
+	case o.description == "":
+		return o.reason
+	}
+	return o.description + ", " + o.reason
+}
+
+// optionList is a list of options indexed by row: the options of a field, or
+// the pages loaded from an OptionSource.
+type optionList[T comparable] interface {
+	// Len returns the number of options.
+	Len() int
+
+	// At returns the option at index i.
+	At(i int) Option[T]
+}
+
+// optionSlice is an optionList of a slice of options.
+type optionSlice[T comparable] []Option[T]
+
+// Len returns the number of options.
+func (s optionSlice[T]) Len() int { return len(s) }
+
+// At returns the option at index i.
+func (s optionSlice[T]) At(i int) Option[T] { return s[i] }
+
+// nextOption returns the index of the next option after i in the direction
+// dir that can be selected, wrapping around the options if wrap is set. It
+// returns i if there is none.
+func nextOption[T comparable](options optionList[T], i, dir int, wrap bool) int {
+	n := options.Len()
+	for j, k := i+dir, 0; k < n; j, k = j+dir, k+1 {
+		if j < 0 || j >= n {
+			if !wrap {
+				break
+			}
+			j = (j + n) % n
+		}
+		if options.At(j).selectable() {
+			return j
+		}
+	}
+	return i
+}
+
+// nearestOption returns the index of the option nearest to i that can be
+// selected, looking in the direction dir first. It returns i if there is
+// none.
+func nearestOption[T comparable](options optionList[T], i, dir int) int {
+	if i >= 0 && i < options.Len() && options.At(i).selectable() {
+		return i
+	}
+	if j := nextOption(options, i, dir, false); j != i {
+		return j
+	}
+	return nextOption(options, i, -dir, false)
+}
+
+// firstRowOf returns the index of the first row shown with the option at i,
+// which is the header or separator above it, if any.
+func firstRowOf[T comparable](options optionList[T], i int) int {
+	for i > 0 && i < options.Len() && !options.At(i-1).isChoice() {
+		i--
+	}
+	return i
+}
+
+// renderOption renders a row of the options: the title of a group header, a
+// separator, or the key of a choice with the characters matching the filter
+// highlighted, followed by its details.
+func renderOption[T comparable](option Option[T], filter string, fn FilterFunc, style lipgloss.Style, styles *FieldStyles) string {
+	switch option.kind {
+	case optionHeader:
+		return styles.OptionHeader.Render(option.Key)
+	case optionSeparator:
+		return styles.OptionSeparator.String()
+	}
+	if option.disabled {
+		style = styles.DisabledOption
+	}
+	view := highlightMatches(option.Key, filter, fn, style, styles.MatchHighlight)
+	if details := option.details(); details != "" {
+		view += " " + styles.OptionDescription.Render(details)
+	}
+	return view
+}
+
+// printChoices prints the choices among the options for accessible mode,
+// numbered from 1, under the titles of their groups. The mark func returns
+// text to print before the key of a choice. It returns the indexes of the
+// choices in the options.
+func printChoices[T comparable](w io.Writer, options []Option[T], mark func(Option[T]) string) []int {
+	var choices []int
+	for i, option := range options {
+		switch option.kind {
+		case optionHeader:
+			fmt.Fprintf(w, "%s:\n", plain(option.Key))
+			continue
+		case optionSeparator:
+			continue
+		}
+		choices = append(choices, i)
+		text := plain(option.Key)
+		if option.description != "" {
+			text += " - " + plain(option.description)
+		}
+		if option.disabled {
+			text += " (disabled"
+			if option.reason != "" {
+				text += ": " + plain(option.reason)
+			}
+			text += ")"
+		}
+		fmt.Fprintf(w, "%d. %s%s\n", len(choices), mark(option), text)
+	}
+	return choices
+}
+
+// errOptionDisabled returns the error of a disabled option being chosen.
+func errOptionDisabled[T comparable](option Option[T]) error {
+	if option.reason != "" {
+		return fmt.Errorf("%s is disabled: %s", plain(option.Key), option.reason)
+	}
+	return fmt.Errorf("%s is disabled", plain(option.Key))
+}
@@ -0,0 +1,253 @@
+package huh
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// optionPageSize is the number of options requested from an OptionSource at
+// a time.
+const optionPageSize = 100
+
+// OptionQuery is a request for a page of options.
+type OptionQuery struct {
+	// Filter is the filter typed by the user, empty when not filtering.
+	Filter string
+
+	// Offset is the index of the first option of the page among all the
+	// options matching the filter.
+	Offset int
+
+	// Limit is the maximum number of options to return.
+	Limit int
+}
+
+// OptionPage is a page of options returned by an OptionSource.
+type OptionPage[T comparable] struct {
+	// Options are the options of the page, in order.
+	Options []Option[T]
+
+	// Total is the number of options matching the filter across all pages.
+	Total int
+}
+
+// OptionSource loads the options of a select field on demand, for lists that
+// are too large to compute up front.
+//
+// Fetch is called from a command, off the main loop. Its context is cancelled
+// when the result is no longer needed, such as when the filter changes.
+type OptionSource[T comparable] interface {
+	Fetch(ctx context.Context, query OptionQuery) (OptionPage[T], error)
+}
+
+// OptionSourceFunc is a function that implements OptionSource.
+type OptionSourceFunc[T comparable] func(ctx context.Context, query OptionQuery) (OptionPage[T], error)
+
+// Fetch calls f(ctx, query).
+func (f OptionSourceFunc[T]) Fetch(ctx context.Context, query OptionQuery) (OptionPage[T], error) {
+	return f(ctx, query)
+}
+
+// optionFilterDebounce is how long an options source waits for the filter to
+// settle, such as the user to stop typing, before fetching the options
+// matching it.
+const optionFilterDebounce = 200 * time.Millisecond
+
+// optionPageMsg is sent with a page of options fetched from a source.
+type optionPageMsg[T comparable] struct {
+	id     int
+	seq    int
+	offset int
+	page   OptionPage[T]
+	err    error
+}
+
+// optionFilterMsg is sent when the filter of a field with an options source
+// has settled.
+type optionFilterMsg struct {
+	id  int
+	seq int
+}
+
+// optionLoader keeps track of the options loaded from an OptionSource for the
+// current filter.
+//
+// Loaded pages are kept by offset, so that memory grows with the pages the
+// user scrolled through rather than with the number of options. Changing the
+// filter cancels pending fetches and discards their results.
+type optionLoader[T comparable] struct {
+	id     int
+	source OptionSource[T]
+
+	seq       int
+	filter    string
+	ctx       context.Context
+	cancel    context.CancelFunc
+	pages     map[int][]Option[T]
+	indexes   map[T]int
+	requested map[int]bool
+	total     int
+	err       error
+
+	// offset is the index of the first option in view. Only the options in
+	// view are rendered.
+	offset int
+
+	// pending is the filter waiting to settle before the options are
+	// fetched again, and debounce the sequence number of its timer.
+	pending  string
+	debounce int
+
+	// locate is set while the cursor should move to the field's value once
+	// the option holding it is loaded.
+	locate bool
+}
+
+// newOptionLoader returns a loader fetching options from the source for the
+// field with the given id.
+func newOptionLoader[T comparable](id int, source OptionSource[T]) *optionLoader[T] {
+	l := &optionLoader[T]{id: id, source: source, locate: true}
+	l.reset("")
+	return l
+}
+
+// reset discards the loaded options and starts over with the given filter.
+func (l *optionLoader[T]) reset(filter string) {
+	if l.cancel != nil {
+		l.cancel()
+	}
+	l.seq++
+	l.debounce++
+	l.filter, l.pending = filter, filter
+	l.ctx, l.cancel = context.WithCancel(context.Background())
+	l.pages = make(map[int][]Option[T])
+	l.indexes = make(map[T]int)
+	l.requested = make(map[int]bool)
+	l.total = -1
+	l.offset = 0
+	l.err = nil
+}
+
+// setFilter schedules fetching the options matching the filter once it has
+// settled, unless it is already current or pending.
+func (l *optionLoader[T]) setFilter(filter string) tea.Cmd {
+	if filter == l.pending {
+		return nil
+	}
+	l.pending = filter
+	l.debounce++
+	if filter == l.filter {
+		// Back to the current filter, the timer is stale.
+		return nil
+	}
+	id, seq := l.id, l.debounce
+	return tea.Tick(optionFilterDebounce, func(time.Time) tea.Msg {
+		return optionFilterMsg{id: id, seq: seq}
+	})
+}
+
+// settled returns whether the message is the one of the pending filter.
+func (l *optionLoader[T]) settled(msg optionFilterMsg) bool {
+	return msg.id == l.id && msg.seq == l.debounce && l.pending != l.filter
+}
+
+// fetch returns a command fetching the pages covering the options from index
+// from to index to, skipping pages already requested. Until the first page
+// arrives only the first page is fetched.
+func (l *optionLoader[T]) fetch(from, to int) tea.Cmd {
+	if l.total < 0 {
+		from, to = 0, 1
+	}
+	if l.total >= 0 {
+		to = min(to, l.total)
+	}
+
+	var cmds []tea.Cmd
+	for offset := max(from, 0) / optionPageSize * optionPageSize; offset < to; offset += optionPageSize {
+		if l.requested[offset] {
+			continue
+		}
+		l.requested[offset] = true
+		cmds = append(cmds, l.fetchPage(offset))
+	}
+	return tea.Batch(cmds...)
+}
+
+func (l *optionLoader[T]) fetchPage(offset int) tea.Cmd {
+	ctx, source, id, seq := l.ctx, l.source, l.id, l.seq
+	query := OptionQuery{Filter: l.filter, Offset: offset, Limit: optionPageSize}
+	return func() tea.Msg {
+		page, err := source.Fetch(ctx, query)
+		return optionPageMsg[T]{id: id, seq: seq, offset: offset, page: page, err: err}
+	}
+}
+
+// update stores a fetched page. It reports whether the page belongs to the
+// current filter.
+//
+// Pages that failed to load are not fetched again until the filter changes.
+func (l *optionLoader[T]) update(msg optionPageMsg[T]) bool {
+	if msg.id != l.id || msg.seq != l.seq {
+		return false
+	}
+	if msg.err != nil {
+		l.err = msg.err
+		return true
+	}
+
+	l.err = nil
+	options := msg.page.Options
+	if len(options) > optionPageSize {
+		options = options[:optionPageSize]
+	}
+	l.total = max(msg.page.Total, msg.offset+len(options))
+	l.pages[msg.offset] = options
+	for i, option := range options {
+		if j, ok := l.indexes[option.Value]; !ok || msg.offset+i < j {
+			l.indexes[option.Value] = msg.offset + i
+		}
+	}
+	return true
+}
+
+// Len returns the number of options matching the filter, loaded or not.
+func (l *optionLoader[T]) Len() int {
+	return max(l.total, 0)
+}
+
+// At returns the option at index i, or a zero option if it is not loaded.
+func (l *optionLoader[T]) At(i int) Option[T] {
+	page := l.pages[i/optionPageSize*optionPageSize]
+	if i < 0 || i%optionPageSize >= len(page) {
+		return Option[T]{}
+	}
+	return page[i%optionPageSize]
+}
+
+// isLoaded returns whether the option at index i has been loaded.
+func (l *optionLoader[T]) isLoaded(i int) bool {
+	return i >= 0 && i%optionPageSize < len(l.pages[i/optionPageSize*optionPageSize])
+}
+
+// indexOf returns the index of the loaded option with the given value, or -1.
+func (l *optionLoader[T]) indexOf(value T) int {
+	if i, ok := l.indexes[value]; ok {
+		return i
+	}
+	return -1
+}
+
+// keepInView scrolls the options so that the option at index i is in view,
+// the view showing height options.
+func (l *optionLoader[T]) keepInView(i, height int) {
+	switch {
+	case i < 0:
+	case i < l.offset:
+		l.offset = i
+	case i >= l.offset+height:
+		l.offset = i - height + 1
+	}
+	l.offset = clamp(l.offset, 0, max(l.Len()-height, 0))
+}
@@ -0,0 +1,190 @@
+package huh
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// Store saves the answers of a form while it is in progress, so that they
+// can be restored if the form is aborted and run again.
+type Store interface {
+	// Load returns the saved answers by key, or none if there are none.
+	Load() (map[string]any, error)
+
+	// Save replaces the saved answers.
+	Save(answers map[string]any) error
+
+	// Clear removes the saved answers.
+	Clear() error
+}
+
+// FileStore is a Store keeping answers in a JSON file.
+type FileStore struct {
+	path string
+}
+
+// NewFileStore returns a store keeping answers in the JSON file at path. The
+// file is created when answers are first saved, and removed when they are
+// cleared.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Load reads the answers from the file.
+func (s *FileStore) Load() (map[string]any, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	answers := make(map[string]any)
+	if err := json.Unmarshal(data, &answers); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// Save writes the answers to the file, readable by its owner only since
+// answers may be personal.
+//
+// The answers are written to a temporary file first, so that the previous
+// answers are kept if writing fails.
+func (s *FileStore) Save(answers map[string]any) error {
+	data, err := json.MarshalIndent(answers, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
+
+// Clear removes the file.
+func (s *FileStore) Clear() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// sensitiveField is implemented by fields whose answer must never be saved,
+// such as password inputs.
+type sensitiveField interface {
+	sensitive() bool
+}
+
+// WithPersistence saves the answers of the form to the store as the user
+// completes each field, and restores them into the fields the next time the
+// form is run, so that no input is lost when the form is aborted. The saved
+// answers are cleared once the form is submitted.
+//
+// Answers that no longer pass validation are not restored, and prefilled
+// answers, such as those given with WithAnswers, take precedence over them.
+// Restoring runs asynchronous validation too, synchronously, before the form
+// is first shown, so slow validators such as network lookups delay its start.
+// The answers to password inputs are never saved; see WithPersistenceExclude
+// to leave out other fields.
+//
+// Errors saving or clearing answers don't interrupt the form, they are
+// returned by Run once it ends.
+func (f *Form) WithPersistence(store Store) *Form {
+	f.store = store
+	return f
+}
+
+// WithPersistenceFile saves the answers of the form to a JSON file at path.
+// See WithPersistence.
+func (f *Form) WithPersistenceFile(path string) *Form {
+	return f.WithPersistence(NewFileStore(path))
+}
+
+// WithPersistenceExclude sets the keys of the fields whose answers must not
+// be saved, such as sensitive answers.
+func (f *Form) WithPersistenceExclude(keys ...string) *Form {
+	if f.persistExclude == nil {
+		f.persistExclude = make(map[string]bool)
+	}
+	for _, key := range keys {
+		f.persistExclude[key] = true
+	}
+	return f
+}
+
+// restore restores the saved answers into the fields, once.
+func (f *Form) restore() error {
+	if f.store == nil || f.restored {
+		return nil
+	}
+	f.restored = true
+
+	answers, err := f.store.Load()
+	if err != nil {
+		return fmt.Errorf("huh: loading saved answers: %w", err)
+	}
+	f.selector.Range(func(_ int, group *Group) bool {
+		group.selector.Range(func(_ int, field Field) bool {
+			key := field.GetKey()
+			value, ok := answers[key]
+			if !ok || !f.persisted(field) {
+				return true
+			}
+			if setter, ok := field.(valueSetter); ok && setter.setValue(value) == nil {
+				f.results[key] = field.GetValue()
+			}
+			return true
+		})
+		return true
+	})
+	f.UpdateFieldPositions()
+	return nil
+}
+
+// persisted returns whether the answer to the field is saved.
+func (f *Form) persisted(field Field) bool {
+	if field.GetKey() == "" || f.persistExclude[field.GetKey()] {
+		return false
+	}
+	s, ok := field.(sensitiveField)
+	return !ok || !s.sensitive()
+}
+
+// save saves the current answers to the store.
+func (f *Form) save() {
+	if f.store == nil {
+		return
+	}
+	answers := make(map[string]any)
+	f.selector.Range(func(_ int, group *Group) bool {
+		group.selector.Range(func(_ int, field Field) bool {
+			if f.persisted(field) {
+				answers[field.GetKey()] = field.GetValue()
+			}
+			return true
+		})
+		return true
+	})
+	if err := f.store.Save(answers); err != nil {
+		f.persistErr = fmt.Errorf("huh: saving answers: %w", err)
+	}
+}
+
+// clearSaved clears the saved answers once the form is complete.
+func (f *Form) clearSaved() {
+	if f.store == nil {
+		return
+	}
+	if err := f.store.Clear(); err != nil {
+		f.persistErr = errors.Join(f.persistErr, fmt.Errorf("huh: clearing saved answers: %w", err))
+	}
+}
@@ -0,0 +1,199 @@
+package huh
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PreviewPosition is where the preview of the option under the cursor is
+// shown in a select field.
+type PreviewPosition int
+
+const (
+	// PreviewRight shows the preview in a pane to the right of the options.
+	PreviewRight PreviewPosition = iota
+
+	// PreviewBottom shows the preview in a pane below the options.
+	PreviewBottom
+)
+
+const (
+	// minPreviewWidth is the smallest width of the options and of a preview
+	// pane next to them. Narrower fields show the preview inline, below the
+	// options and without a pane.
+	minPreviewWidth = 20
+
+	// defaultPreviewHeight is the height of a preview below the options.
+	defaultPreviewHeight = 6
+)
+
+// optionPreview renders the preview of the option under the cursor of a
+// select field.
+//
+// Previews are computed in a command every time the cursor moves to another
+// option and cached by the hash of its value, like an Eval bound to it.
+type optionPreview[T comparable] struct {
+	id int
+	fn func(T) string
+
+	text     Eval[string]
+	viewport viewport.Model
+	position PreviewPosition
+	size     int
+}
+
+// updatePreviewMsg is sent with the preview of an option.
+type updatePreviewMsg struct {
+	id      int
+	hash    uint64
+	preview string
+}
+
+// newOptionPreview returns the preview of the field with the given id. It is
+// shown once its function is set.
+func newOptionPreview[T comparable](id int) *optionPreview[T] {
+	return &optionPreview[T]{
+		id:   id,
+		text: Eval[string]{cache: make(map[uint64]string)},
+	}
+}
+
+// enabled returns whether the preview has a function to render it.
+func (p *optionPreview[T]) enabled() bool {
+	return p != nil && p.fn != nil
+}
+
+// hover computes the preview of the option under the cursor, unless it is
+// the same option as before. Cached previews are shown right away.
+func (p *optionPreview[T]) hover(value T) tea.Cmd {
+	if !p.enabled() {
+		return nil
+	}
+	fn := p.fn
+	p.text.fn = func() string { return fn(value) }
+	p.text.bindings = value
+	ok, hash := p.text.shouldUpdate()
+	if !ok {
+		return nil
+	}
+	p.text.bindingsHash = hash
+	p.viewport.GotoTop()
+	if p.text.loadFromCache() {
+		return nil
+	}
+	p.text.loading = true
+	p.text.loadingStart = time.Now()
+	id, compute := p.id, p.text.fn
+	return func() tea.Msg {
+		return updatePreviewMsg{id: id, hash: hash, preview: compute()}
+	}
+}
+
+// clear clears the preview when there is no option under the cursor.
+func (p *optionPreview[T]) clear() {
+	if !p.enabled() {
+		return
+	}
+	p.text.val = ""
+	p.text.loading = false
+	p.text.bindingsHash = 0
+}
+
+// update sets the computed preview, unless the cursor moved to another
+// option meanwhile.
+func (p *optionPreview[T]) update(msg tea.Msg) {
+	if msg, ok := msg.(updatePreviewMsg); ok && p.enabled() && msg.id == p.id && msg.hash == p.text.bindingsHash {
+		p.text.update(msg.preview)
+	}
+}
+
+// scroll scrolls the preview by the given number of lines.
+func (p *optionPreview[T]) scroll(lines int) {
+	if !p.enabled() {
+		return
+	}
+	if lines < 0 {
+		p.viewport.LineUp(-lines)
+	} else {
+		p.viewport.LineDown(lines)
+	}
+}
+
+// layout returns where the preview is shown in a field of the given width,
+// and whether it is shown in a pane. A preview too wide for the field is
+// shown inline below the options instead.
+func (p *optionPreview[T]) layout(width int) (PreviewPosition, bool) {
+	switch {
+	case p.position == PreviewRight && width >= 2*minPreviewWidth:
+		return PreviewRight, true
+	case p.position == PreviewBottom && width >= minPreviewWidth:
+		return PreviewBottom, true
+	}
+	return PreviewBottom, false
+}
+
+// height returns the number of lines the preview takes below the options in
+// a field of the given width.
+func (p *optionPreview[T]) height(width int, styles *FieldStyles) int {
+	if !p.enabled() {
+		return 0
+	}
+	position, pane := p.layout(width)
+	if position == PreviewRight {
+		return 0
+	}
+	height := p.size
+	if height <= 0 {
+		height = defaultPreviewHeight
+	}
+	if pane {
+		height += styles.PreviewPane.GetVerticalFrameSize()
+	}
+	return height
+}
+
+// view renders the options along with the preview in a field of the given
+// width.
+func (p *optionPreview[T]) view(options string, width int, styles *FieldStyles) string {
+	if !p.enabled() {
+		return options
+	}
+
+	text := p.text.val
+	if p.text.loading && time.Since(p.text.loadingStart) > spinnerShowThreshold {
+		text = styles.TextInput.Placeholder.Render("Loading...")
+	}
+
+	position, pane := p.layout(width)
+	frameWidth, frameHeight := styles.PreviewPane.GetFrameSize()
+	if !pane {
+		frameWidth, frameHeight = 0, 0
+	}
+
+	if position == PreviewRight {
+		paneWidth := p.size
+		if paneWidth <= 0 {
+			paneWidth = width / 2
+		}
+		paneWidth = clamp(paneWidth, minPreviewWidth, width-minPreviewWidth)
+		p.viewport.Width = paneWidth - frameWidth
+		p.viewport.Height = max(lipgloss.Height(options)-frameHeight, 1)
+		p.viewport.SetContent(lipgloss.NewStyle().Width(p.viewport.Width).Render(text))
+		options = lipgloss.NewStyle().Width(width - paneWidth).MaxWidth(width - paneWidth).Render(options)
+		return lipgloss.JoinHorizontal(lipgloss.Top, options, styles.PreviewPane.Render(p.viewport.View()))
+	}
+
+	p.viewport.Width = max(width-frameWidth, 0)
+	p.viewport.Height = p.height(width, styles) - frameHeight
+	if p.viewport.Width > 0 {
+		text = lipgloss.NewStyle().Width(p.viewport.Width).Render(text)
+	}
+	p.viewport.SetContent(text)
+	if !pane {
+		return options + "\n" + p.viewport.View()
+	}
+	return options + "\n" + styles.PreviewPane.Render(p.viewport.View())
+}
@@ -0,0 +1,119 @@
+package huh
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// progressBarWidth is the number of cells of a progress bar.
+const progressBarWidth = 30
+
+// Progress is how a form shows the user's progress through its groups.
+//
+// Only visible groups count as steps, so the progress changes as groups are
+// hidden or shown by the answers given.
+type Progress int
+
+const (
+	// ProgressNone shows no progress.
+	ProgressNone Progress = iota
+
+	// ProgressCounter shows the step the user is on, such as "Step 2 of 5".
+	ProgressCounter
+
+	// ProgressBar shows a bar filling up as the user moves through the
+	// groups.
+	ProgressBar
+
+	// ProgressBreadcrumbs shows the titles of the groups, highlighting the
+	// group the user is on. Groups without a title are shown as "Step N".
+	ProgressBreadcrumbs
+)
+
+// WithProgress sets how the form shows the user's progress through its
+// groups, above the groups whatever the layout.
+func (f *Form) WithProgress(progress Progress) *Form {
+	f.progress = progress
+	return f
+}
+
+// steps returns the titles of the visible groups, and the index of the group
+// at index current among them.
+func (f *Form) steps(current int) ([]string, int) {
+	var (
+		titles []string
+		step   int
+	)
+	f.selector.Range(func(i int, group *Group) bool {
+		if f.isGroupHidden(group) {
+			return true
+		}
+		if i < current {
+			step++
+		}
+		title := plain(group.title)
+		if title == "" {
+			title = fmt.Sprintf("Step %d", len(titles)+1)
+		}
+		titles = append(titles, title)
+		return true
+	})
+	return titles, step
+}
+
+// progressView renders the user's progress through the groups.
+func (f *Form) progressView() string {
+	if f.progress == ProgressNone {
+		return ""
+	}
+
+	theme := f.theme
+	if theme == nil {
+		theme = ThemeCharm()
+	}
+	styles := theme.Progress
+
+	titles, step := f.steps(f.selector.Index())
+	total := len(titles)
+	// The review page comes after all the groups.
+	reviewing := f.Reviewing()
+	if reviewing {
+		step = total
+	}
+
+	var view string
+	switch f.progress {
+	case ProgressCounter:
+		if reviewing {
+			view = styles.Counter.Render(reviewTitle)
+		} else {
+			view = styles.Counter.Render(fmt.Sprintf("Step %d of %d", step+1, total))
+		}
+	case ProgressBar:
+		done := min(step+1, total)
+		filled := progressBarWidth * done / max(total, 1)
+		view = strings.Repeat(styles.BarFilled.String(), filled) +
+			strings.Repeat(styles.BarEmpty.String(), progressBarWidth-filled) +
+			" " + styles.Counter.Render(fmt.Sprintf("%d/%d", done, total))
+	case ProgressBreadcrumbs:
+		crumbs := make([]string, len(titles))
+		for i, title := range titles {
+			switch {
+			case i < step:
+				crumbs[i] = styles.Completed.Render(title)
+			case i == step:
+				crumbs[i] = styles.Current.Render(title)
+			default:
+				crumbs[i] = styles.Pending.Render(title)
+			}
+		}
+		view = strings.Join(crumbs, styles.Separator.String())
+	}
+
+	if width := f.selector.Selected().width; width > 0 {
+		view = ansi.Truncate(view, width-styles.Base.GetHorizontalFrameSize(), "…")
+	}
+	return styles.Base.Render(view)
+}
@@ -0,0 +1,304 @@
+package huh
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+const (
+	reviewTitle       = "Review your answers"
+	reviewDescription = "Pick an answer to change it, or submit the form."
+)
+
+// review is the state of the review page of a form.
+type review struct {
+	// active is set while the review page is displayed.
+	active bool
+
+	// returning is set while a group picked on the review page is edited,
+	// so that completing it goes back to the review page.
+	returning bool
+
+	// shown is whether each group was shown when the edit started, so that
+	// groups shown by the new answers are completed before going back.
+	shown []bool
+
+	cursor int
+	err    error
+}
+
+// reviewEntry is an answer listed on the review page.
+type reviewEntry struct {
+	group  int
+	field  int
+	title  string
+	answer string
+}
+
+// summarizer is implemented by fields that describe their answer for the
+// review page better than its printed value, such as a select field showing
+// the key of its option.
+type summarizer interface {
+	summarize() (title, answer string)
+}
+
+// WithReview sets whether the form shows a review page once the last group
+// is complete.
+//
+// The review page lists the answer to each field with a key. Picking an
+// answer goes back to its field to change it, then back to the review page
+// once its group is complete. The form is submitted from the review page.
+func (f *Form) WithReview(v bool) *Form {
+	if !v {
+		f.review = nil
+	} else if f.review == nil {
+		f.review = &review{}
+	}
+	return f
+}
+
+// Reviewing returns whether the form is displaying its review page.
+func (f *Form) Reviewing() bool {
+	return f.review != nil && f.review.active
+}
+
+// reviewEntries returns the answers to the fields with a key in the visible
+// groups.
+func (f *Form) reviewEntries() []reviewEntry {
+	var entries []reviewEntry
+	f.selector.Range(func(g int, group *Group) bool {
+		if f.isGroupHidden(group) {
+			return true
+		}
+		group.selector.Range(func(i int, field Field) bool {
+			key := field.GetKey()
+			if key == "" || field.Skip() {
+				return true
+			}
+			title, answer := "", fmt.Sprint(field.GetValue())
+			if s, ok := field.(summarizer); ok {
+				title, answer = s.summarize()
+			}
+			if title = plain(title); title == "" {
+				title = key
+			}
+			entries = append(entries, reviewEntry{group: g, field: i, title: title, answer: plain(answer)})
+			return true
+		})
+		return true
+	})
+	return entries
+}
+
+// showReview displays the review page.
+func (f *Form) showReview() {
+	f.review.active = true
+	f.review.returning = false
+	f.review.err = nil
+	// Answers may have hidden or shown groups since they were completed.
+	f.UpdateFieldPositions()
+	f.review.cursor = clamp(f.review.cursor, 0, max(len(f.reviewEntries())-1, 0))
+}
+
+// updateReview handles keys on the review page.
+func (f *Form) updateReview(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	keymap := f.keymap.Review
+	entries := f.reviewEntries()
+
+	switch {
+	case key.Matches(msg, keymap.Up):
+		f.review.cursor = max(f.review.cursor-1, 0)
+	case key.Matches(msg, keymap.Down):
+		f.review.cursor = clamp(f.review.cursor+1, 0, max(len(entries)-1, 0))
+	case key.Matches(msg, keymap.Edit):
+		if f.review.cursor >= len(entries) {
+			break
+		}
+		entry := entries[f.review.cursor]
+		f.review.active = false
+		f.review.returning = true
+		f.review.shown = f.groupsShown()
+		f.selector.SetIndex(entry.group)
+		group := f.selector.Selected()
+		group.active = true
+		return f, tea.Batch(group.focusField(entry.field), updateFields)
+	case key.Matches(msg, keymap.Prev):
+		// Go back to the last visible group.
+		f.review.active = false
+		for i := f.selector.Total() - 1; i >= 0; i-- {
+			if !f.isGroupHidden(f.selector.Get(i)) {
+				f.selector.SetIndex(i)
+				break
+			}
+		}
+		f.selector.Selected().active = true
+		return f, tea.Batch(f.selector.Selected().Init(), updateFields)
+	case key.Matches(msg, keymap.Submit):
+		if f.review.err = f.validateReview(); f.review.err != nil {
+			return f, nil
+		}
+		return f, f.complete()
+	default:
+		// Any other input dismisses the validation error.
+		f.review.err = nil
+	}
+	return f, nil
+}
+
+// groupsShown returns whether each group of the form is shown.
+func (f *Form) groupsShown() []bool {
+	shown := make([]bool, f.selector.Total())
+	f.selector.Range(func(i int, group *Group) bool {
+		shown[i] = !f.isGroupHidden(group)
+		return true
+	})
+	return shown
+}
+
+// newlyShown returns the index of the first group after the given one that
+// is shown now but wasn't before, or -1 if there is none.
+func (f *Form) newlyShown(after int, before []bool) int {
+	for i := after + 1; i < f.selector.Total(); i++ {
+		if (i >= len(before) || !before[i]) && !f.isGroupHidden(f.selector.Get(i)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// reviewView renders the review page.
+func (f *Form) reviewView() string {
+	theme := f.theme
+	if theme == nil {
+		theme = ThemeCharm()
+	}
+	styles := theme.Focused
+	entries := f.reviewEntries()
+
+	var width int
+	for _, entry := range entries {
+		width = max(width, lipgloss.Width(entry.title))
+	}
+
+	var (
+		c  = styles.SelectSelector.String()
+		sb strings.Builder
+	)
+	sb.WriteString(styles.Title.Render(reviewTitle))
+	sb.WriteString("\n")
+	sb.WriteString(styles.Description.Render(reviewDescription))
+	for i, entry := range entries {
+		prefix, style := strings.Repeat(" ", lipgloss.Width(c)), styles.UnselectedOption
+		if i == f.review.cursor {
+			prefix, style = c, styles.SelectedOption
+		}
+		title := entry.title + strings.Repeat(" ", width-lipgloss.Width(entry.title))
+		sb.WriteString("\n" + prefix + styles.Description.Render(title) + "  " + style.Render(entry.answer))
+	}
+
+	var footer strings.Builder
+	footer.WriteRune('\n')
+	if f.review.err != nil {
+		footer.WriteString(styles.ErrorMessage.Render(f.review.err.Error()))
+	} else {
+		keymap := f.keymap.Review
+		footer.WriteString(f.Help().ShortHelpView([]key.Binding{keymap.Up, keymap.Down, keymap.Edit, keymap.Prev, keymap.Submit}))
+	}
+
+	return styles.Base.Render(sb.String()) + "\n" + footer.String()
+}
+
+// runReviewAccessible lists the answers of the form in accessible mode, and
+// lets the user change any of them before submitting the form.
+func (f *Form) runReviewAccessible(a *accessibleRenderer, w io.Writer, r io.Reader) error {
+	for {
+		entries := f.reviewEntries()
+		a.announce(reviewTitle, "Enter the number of an answer to change it, or 0 to submit the form.")
+		for i, entry := range entries {
+			fmt.Fprintf(w, "%d. %s: %s\n", i+1, entry.title, entry.answer)
+		}
+		input, err := a.prompt("Change", "0", validChoice(0, len(entries)))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+
+		if i, _ := strconv.Atoi(input); i > 0 {
+			entry := entries[i-1]
+			shown := f.groupsShown()
+			group := f.selector.Get(entry.group)
+			field := group.selector.Get(entry.field)
+			field.Init()
+			field.Focus()
+			if err := runAccessible(field, w, r); err != nil {
+				return err
+			}
+			f.save()
+			// Ask the groups shown by the new answer.
+			for g := f.newlyShown(entry.group, shown); g >= 0; g = f.newlyShown(g, shown) {
+				if err := f.askGroupAccessible(a, w, r, f.selector.Get(g)); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		if err := f.validateReview(); err != nil {
+			a.reject(err)
+			fmt.Fprintln(w)
+			continue
+		}
+		return nil
+	}
+}
+
+// askGroupAccessible asks the questions of a group in accessible mode, until
+// the group passes validation.
+func (f *Form) askGroupAccessible(a *accessibleRenderer, w io.Writer, r io.Reader, group *Group) error {
+	if plain(group.title) != "" || plain(group.description) != "" {
+		a.announce(group.title, group.description)
+		fmt.Fprintln(w)
+	}
+	for {
+		for j := 0; j < group.selector.Total(); j++ {
+			field := group.selector.Get(j)
+			if isFieldHidden(field) {
+				continue
+			}
+			field.Init()
+			field.Focus()
+			if err := runAccessible(field, w, r); err != nil {
+				return err
+			}
+			f.save()
+		}
+		err := group.runValidation()
+		if err == nil {
+			return nil
+		}
+		a.reject(err)
+		fmt.Fprintln(w)
+	}
+}
+
+// validateReview runs the validation of the visible groups, then of the
+// form, as answers changed on the review page may no longer pass them.
+func (f *Form) validateReview() error {
+	var err error
+	f.selector.Range(func(_ int, group *Group) bool {
+		if !f.isGroupHidden(group) {
+			err = group.runValidation()
+		}
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	return f.runValidation()
+}
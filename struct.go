@@ -0,0 +1,588 @@
+package huh
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// structTag is the struct tag read by NewFormFromStruct.
+const structTag = "huh"
+
+// Enum is implemented by enum-like types to list their possible values.
+//
+// When a struct field's type implements Enum, NewFormFromStruct renders it as
+// a Select, or as a MultiSelect for slices of the type, with one option per
+// value. Option keys are formatted with fmt.Sprint, so implementing
+// fmt.Stringer controls how the values are displayed.
+type Enum interface {
+	Values() []any
+}
+
+var (
+	enumType = reflect.TypeOf((*Enum)(nil)).Elem()
+	timeType = reflect.TypeOf(time.Time{})
+)
+
+// NewFormFromStruct returns a form generated from the exported fields of the
+// struct pointed to by v.
+//
+// Each field is configured with a `huh` struct tag made of comma separated
+// key=value pairs, for example:
+//
+//	type Config struct {
+//	    Name   string   `huh:"title=Name,validate=notempty"`
+//	    Bio    string   `huh:"title=Bio,kind=text"`
+//	    Shell  string   `huh:"title=Shell,options=bash|zsh|fish"`
+//	    Color  Color    `huh:"title=Favorite color"` // Color implements Enum
+//	    Agree  bool     `huh:"title=Do you agree?"`
+//	    Port   int      `huh:"title=Port,min=1,max=65535"`
+//	    Server struct {
+//	        Host string `huh:"title=Host"`
+//	    } `huh:"title=Server"`
+//	}
+//
+// The supported keys are:
+//
+//   - title: the title of the field, or of the group for struct fields.
+//   - description: the description of the field or group.
+//   - kind: one of input, text, confirm, select, multiselect, number, date or
+//     datetime. When omitted the kind is inferred from the field's type, and
+//     time.Time fields are date pickers.
+//   - key: the key of the field, defaults to the field's path (e.g. Server.Host).
+//   - placeholder: the placeholder of input and text fields.
+//   - options: pipe separated options for select and multiselect fields.
+//   - validate: pipe separated validators for input and text fields; see
+//     below.
+//   - limit: the selection limit of multiselect fields.
+//   - height: the height of select and multiselect fields.
+//   - inline: whether input, select and confirm fields are inline.
+//   - echo: the echo mode of input fields, one of normal, password or none.
+//   - min, max and step: the bounds and step of number fields. Bounds default
+//     to the range of integer types smaller than 64 bits, and unsigned 64-bit
+//     integers are bounded by the largest int64, as numbers are edited as
+//     int64.
+//
+// The validate key accepts notempty, minlen:N and maxlen:N.
+//
+// A comma within a value is written as two commas, and a pipe within an
+// option as two pipes, for example `huh:"title=Hello,, world"`.
+//
+// Fields tagged `huh:"-"` are ignored. Top-level fields are placed in the
+// first group, nested structs each get a group of their own and embedded
+// structs are flattened into the group of their parent.
+func NewFormFromStruct(v any) (*Form, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, errors.New("huh: NewFormFromStruct expects a non-nil pointer to a struct")
+	}
+
+	b := &structFormBuilder{}
+	if err := b.addGroup(rv.Elem(), "", structTagOptions{}); err != nil {
+		return nil, err
+	}
+	if len(b.groups) == 0 {
+		return nil, errors.New("huh: struct has no fields to build a form from")
+	}
+	return NewForm(b.groups...), nil
+}
+
+// structFormBuilder accumulates the groups generated from a struct.
+type structFormBuilder struct {
+	groups []*Group
+}
+
+// addGroup adds a group for the struct value s, followed by the groups of any
+// nested structs.
+func (b *structFormBuilder) addGroup(s reflect.Value, prefix string, opts structTagOptions) error {
+	var (
+		fields []Field
+		nested []func() error
+	)
+
+	var collect func(s reflect.Value, prefix string) error
+	collect = func(s reflect.Value, prefix string) error {
+		t := s.Type()
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag, ok := sf.Tag.Lookup(structTag)
+			if tag == "-" || (!sf.IsExported() && !sf.Anonymous) {
+				continue
+			}
+			tagOpts, err := parseStructTag(tag)
+			if err != nil {
+				return fmt.Errorf("huh: field %s: %w", sf.Name, err)
+			}
+			fv := s.Field(i)
+			path := prefix + sf.Name
+
+			if sf.Type.Kind() == reflect.Struct && !isEnum(sf.Type) && sf.Type != timeType {
+				if sf.Anonymous && !ok {
+					if err := collect(fv, prefix); err != nil {
+						return err
+					}
+					continue
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				nested = append(nested, func() error {
+					if tagOpts.title == "" {
+						tagOpts.title = sf.Name
+					}
+					return b.addGroup(fv, path+".", tagOpts)
+				})
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+
+			field, err := newStructField(fv, path, tagOpts)
+			if err != nil {
+				return fmt.Errorf("huh: field %s: %w", path, err)
+			}
+			fields = append(fields, field)
+		}
+		return nil
+	}
+
+	if err := collect(s, prefix); err != nil {
+		return err
+	}
+
+	if len(fields) > 0 {
+		group := NewGroup(fields...).Title(opts.title).Description(opts.description)
+		b.groups = append(b.groups, group)
+	}
+	for _, add := range nested {
+		if err := add(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structTagOptions are the parsed options of a `huh` struct tag.
+type structTagOptions struct {
+	title       string
+	description string
+	kind        string
+	key         string
+	placeholder string
+	options     []string
+	validate    string
+	limit       int
+	height      int
+	inline      bool
+	echo        string
+	min         *float64
+	max         *float64
+	step        *float64
+}
+
+// parseStructTag parses a `huh` struct tag.
+func parseStructTag(tag string) (structTagOptions, error) {
+	var opts structTagOptions
+	for _, part := range splitTag(tag, ',') {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(part, "=")
+		k, v = strings.TrimSpace(k), strings.TrimSpace(v)
+
+		var err error
+		switch k {
+		case "title":
+			opts.title = v
+		case "description":
+			opts.description = v
+		case "kind":
+			opts.kind = v
+		case "key":
+			opts.key = v
+		case "placeholder":
+			opts.placeholder = v
+		case "options":
+			opts.options = splitTag(v, '|')
+		case "validate":
+			opts.validate = v
+		case "limit":
+			opts.limit, err = strconv.Atoi(v)
+		case "height":
+			opts.height, err = strconv.Atoi(v)
+		case "inline":
+			opts.inline = v == "" || v == "true"
+		case "echo":
+			opts.echo = v
+		case "min":
+			opts.min, err = parseTagFloat(v)
+		case "max":
+			opts.max, err = parseTagFloat(v)
+		case "step":
+			opts.step, err = parseTagFloat(v)
+		default:
+			return opts, fmt.Errorf("unknown tag key %q", k)
+		}
+		if err != nil {
+			return opts, fmt.Errorf("invalid %s: %w", k, err)
+		}
+	}
+	return opts, nil
+}
+
+// splitTag splits a struct tag value on sep, where a doubled sep stands for
+// sep itself.
+func splitTag(s string, sep byte) []string {
+	var (
+		parts []string
+		part  strings.Builder
+	)
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] != sep:
+			part.WriteByte(s[i])
+		case i+1 < len(s) && s[i+1] == sep:
+			part.WriteByte(sep)
+			i++
+		default:
+			parts = append(parts, part.String())
+			part.Reset()
+		}
+	}
+	return append(parts, part.String())
+}
+
+// parseTagFloat parses a number in a struct tag.
+func parseTagFloat(v string) (*float64, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+// isEnum returns whether the given type implements Enum.
+func isEnum(t reflect.Type) bool {
+	return t.Implements(enumType) || reflect.PointerTo(t).Implements(enumType)
+}
+
+// enumValues returns the values of an Enum type.
+func enumValues(t reflect.Type) []any {
+	return reflect.New(t).Interface().(Enum).Values()
+}
+
+// inferKind infers the kind of field to generate from a struct field's type.
+func inferKind(t reflect.Type, opts structTagOptions) (string, error) {
+	switch {
+	case isEnum(t):
+		return "select", nil
+	case t.Kind() == reflect.Slice && (isEnum(t.Elem()) || len(opts.options) > 0):
+		return "multiselect", nil
+	case t.Kind() == reflect.String && len(opts.options) > 0:
+		return "select", nil
+	case t.Kind() == reflect.String:
+		return "input", nil
+	case t.Kind() == reflect.Bool:
+		return "confirm", nil
+	case isNumberKind(t.Kind()):
+		return "number", nil
+	case t == timeType:
+		return "date", nil
+	}
+	return "", fmt.Errorf("unsupported type %s", t)
+}
+
+// newStructField creates the field bound to the struct field fv.
+func newStructField(fv reflect.Value, path string, opts structTagOptions) (Field, error) {
+	t := fv.Type()
+	kind := opts.kind
+	if kind == "" {
+		var err error
+		kind, err = inferKind(t, opts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	title := opts.title
+	if title == "" {
+		title = path[strings.LastIndex(path, ".")+1:]
+	}
+	key := opts.key
+	if key == "" {
+		key = path
+	}
+
+	var validate func(string) error
+	if opts.validate != "" {
+		if kind != "input" && kind != "text" {
+			return nil, fmt.Errorf("validate is not supported for %s fields", kind)
+		}
+		var err error
+		validate, err = validatorFromSpec(opts.validate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch kind {
+	case "input":
+		if t.Kind() != reflect.String {
+			return nil, fmt.Errorf("kind input requires a string, got %s", t)
+		}
+		input := NewInput().
+			Key(key).
+			Title(title).
+			Description(opts.description).
+			Placeholder(opts.placeholder).
+			Inline(opts.inline).
+			Accessor(newStructFieldAccessor[string](fv))
+		if validate != nil {
+			input.Validate(validate)
+		}
+		switch opts.echo {
+		case "", "normal":
+		case "password":
+			input.EchoMode(EchoModePassword)
+		case "none":
+			input.EchoMode(EchoModeNone)
+		default:
+			return nil, fmt.Errorf("unknown echo mode %q", opts.echo)
+		}
+		return input, nil
+
+	case "text":
+		if t.Kind() != reflect.String {
+			return nil, fmt.Errorf("kind text requires a string, got %s", t)
+		}
+		text := NewText().
+			Key(key).
+			Title(title).
+			Description(opts.description).
+			Placeholder(opts.placeholder).
+			Accessor(newStructFieldAccessor[string](fv))
+		if validate != nil {
+			text.Validate(validate)
+		}
+		return text, nil
+
+	case "confirm":
+		if t.Kind() != reflect.Bool {
+			return nil, fmt.Errorf("kind confirm requires a bool, got %s", t)
+		}
+		return NewConfirm().
+			Key(key).
+			Title(title).
+			Description(opts.description).
+			Inline(opts.inline).
+			Accessor(newStructFieldAccessor[bool](fv)), nil
+
+	case "select":
+		options, err := structOptions(t, opts)
+		if err != nil {
+			return nil, err
+		}
+		s := NewSelect[any]().
+			Key(key).
+			Title(title).
+			Description(opts.description).
+			Options(options...).
+			Accessor(newStructFieldAccessor[any](fv))
+		if opts.height > 0 {
+			s.Height(opts.height)
+		}
+		if opts.inline {
+			s.Inline(true)
+		}
+		return s, nil
+
+	case "number":
+		switch t.Kind() {
+		case reflect.Int:
+			return newStructNumber[int](fv, key, title, opts), nil
+		case reflect.Float32, reflect.Float64:
+			return newStructNumber[float64](fv, key, title, opts), nil
+		}
+		if !isNumberKind(t.Kind()) {
+			return nil, fmt.Errorf("kind number requires a number, got %s", t)
+		}
+		for _, bound := range []*float64{opts.min, opts.max} {
+			if bound != nil && (*bound < math.MinInt64 || *bound >= math.MaxInt64) {
+				return nil, fmt.Errorf("bound %v is out of the int64 range", *bound)
+			}
+		}
+		n := newStructNumber[int64](fv, key, title, opts)
+		bits := t.Bits()
+		switch {
+		case t.Kind() >= reflect.Uint && opts.min == nil:
+			n.Min(0)
+		case t.Kind() < reflect.Uint && bits < 64 && opts.min == nil:
+			n.Min(-1 << (bits - 1))
+		}
+		switch {
+		case t.Kind() >= reflect.Uint && bits < 64 && opts.max == nil:
+			n.Max(1<<bits - 1)
+		case t.Kind() >= reflect.Uint && opts.max == nil:
+			n.Max(math.MaxInt64)
+		case t.Kind() < reflect.Uint && bits < 64 && opts.max == nil:
+			n.Max(1<<(bits-1) - 1)
+		}
+		return n, nil
+
+	case "date", "datetime":
+		if t != timeType {
+			return nil, fmt.Errorf("kind %s requires a time.Time, got %s", kind, t)
+		}
+		d := NewDatePicker()
+		if kind == "datetime" {
+			d = NewDateTime()
+		}
+		return d.
+			Key(key).
+			Title(title).
+			Description(opts.description).
+			Accessor(newStructFieldAccessor[time.Time](fv)), nil
+
+	case "multiselect":
+		if t.Kind() != reflect.Slice {
+			return nil, fmt.Errorf("kind multiselect requires a slice, got %s", t)
+		}
+		options, err := structOptions(t.Elem(), opts)
+		if err != nil {
+			return nil, err
+		}
+		m := NewMultiSelect[any]().
+			Key(key).
+			Title(title).
+			Description(opts.description).
+			Options(options...).
+			Limit(opts.limit).
+			Accessor(&structSliceAccessor{field: fv})
+		if opts.height > 0 {
+			m.Height(opts.height)
+		}
+		return m, nil
+	}
+
+	return nil, fmt.Errorf("unknown kind %q", kind)
+}
+
+// isNumberKind returns whether the given kind is an integer or floating point
+// number.
+func isNumberKind(k reflect.Kind) bool {
+	return (k >= reflect.Int && k <= reflect.Uint64) || k == reflect.Float32 || k == reflect.Float64
+}
+
+// newStructNumber creates a number field bound to the struct field fv.
+func newStructNumber[T int | int64 | float64](fv reflect.Value, key, title string, opts structTagOptions) *Number[T] {
+	n := NewNumber[T]().
+		Key(key).
+		Title(title).
+		Description(opts.description).
+		Placeholder(opts.placeholder).
+		Inline(opts.inline).
+		Accessor(newStructFieldAccessor[T](fv))
+	if opts.min != nil {
+		n.Min(T(*opts.min))
+	}
+	if opts.max != nil {
+		n.Max(T(*opts.max))
+	}
+	if opts.step != nil {
+		n.Step(T(*opts.step))
+	}
+	return n
+}
+
+// structOptions returns the select options for values of type t, either from
+// the options tag or from the values of an Enum type.
+func structOptions(t reflect.Type, opts structTagOptions) ([]Option[any], error) {
+	if len(opts.options) > 0 {
+		if t.Kind() != reflect.String {
+			return nil, fmt.Errorf("options tag requires string values, got %s", t)
+		}
+		options := make([]Option[any], len(opts.options))
+		for i, o := range opts.options {
+			options[i] = NewOption[any](o, reflect.ValueOf(o).Convert(t).Interface())
+		}
+		return options, nil
+	}
+
+	if !isEnum(t) {
+		return nil, fmt.Errorf("%s has no options; use the options tag or implement huh.Enum", t)
+	}
+	values := enumValues(t)
+	options := make([]Option[any], 0, len(values))
+	for _, v := range values {
+		rv := reflect.ValueOf(v)
+		if !rv.IsValid() || !rv.Type().ConvertibleTo(t) {
+			return nil, fmt.Errorf("enum value %v is not a %s", v, t)
+		}
+		v = rv.Convert(t).Interface()
+		options = append(options, NewOption(fmt.Sprint(v), v))
+	}
+	return options, nil
+}
+
+// structFieldAccessor gives read/write access to a struct field whose type is
+// convertible to T.
+type structFieldAccessor[T any] struct {
+	field reflect.Value
+}
+
+// newStructFieldAccessor returns an accessor for the given struct field. When
+// the field is exactly of type T a PointerAccessor is returned instead.
+func newStructFieldAccessor[T any](field reflect.Value) Accessor[T] {
+	if p, ok := field.Addr().Interface().(*T); ok {
+		return NewPointerAccessor(p)
+	}
+	return &structFieldAccessor[T]{field: field}
+}
+
+// Get gets the value.
+func (a *structFieldAccessor[T]) Get() T {
+	return a.field.Convert(reflect.TypeOf((*T)(nil)).Elem()).Interface().(T)
+}
+
+// Set sets the value.
+func (a *structFieldAccessor[T]) Set(value T) {
+	v := reflect.ValueOf(&value).Elem()
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		a.field.SetZero()
+		return
+	}
+	a.field.Set(v.Convert(a.field.Type()))
+}
+
+// structSliceAccessor gives read/write access to a slice struct field as a
+// slice of values.
+type structSliceAccessor struct {
+	field reflect.Value
+}
+
+// Get gets the value.
+func (a *structSliceAccessor) Get() []any {
+	values := make([]any, a.field.Len())
+	for i := range values {
+		values[i] = a.field.Index(i).Interface()
+	}
+	return values
+}
+
+// Set sets the value.
+func (a *structSliceAccessor) Set(values []any) {
+	elem := a.field.Type().Elem()
+	slice := reflect.MakeSlice(a.field.Type(), 0, len(values))
+	for _, v := range values {
+		slice = reflect.Append(slice, reflect.ValueOf(v).Convert(elem))
+	}
+	a.field.Set(slice)
+}
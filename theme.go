@@ -12,9 +12,14 @@ type Theme struct {
 	Form           lipgloss.Style
 	Group          lipgloss.Style
 	FieldSeparator lipgloss.Style
+	Progress       ProgressStyles
 	Blurred        FieldStyles
 	Focused        FieldStyles
 	Help           help.Styles
+
+	// MarkdownDescriptions renders the descriptions of fields as Markdown,
+	// like the descriptions of notes.
+	MarkdownDescriptions bool
 }
 
 // FieldStyles are the styles for input fields.
@@ -26,10 +31,15 @@ type FieldStyles struct {
 	ErrorMessage   lipgloss.Style
 
 	// Select styles.
-	SelectSelector lipgloss.Style // Selection indicator
-	Option         lipgloss.Style // Select options
-	NextIndicator  lipgloss.Style
-	PrevIndicator  lipgloss.Style
+	SelectSelector    lipgloss.Style // Selection indicator
+	Option            lipgloss.Style // Select options
+	OptionHeader      lipgloss.Style // Headers of groups of options
+	OptionSeparator   lipgloss.Style // Set with the line separating options
+	OptionDescription lipgloss.Style
+	DisabledOption    lipgloss.Style
+	PreviewPane       lipgloss.Style // Pane showing the preview of an option
+	NextIndicator     lipgloss.Style
+	PrevIndicator     lipgloss.Style
 
 	// FilePicker styles.
 	Directory lipgloss.Style
@@ -41,6 +51,12 @@ type FieldStyles struct {
 	SelectedPrefix      lipgloss.Style
 	UnselectedOption    lipgloss.Style
 	UnselectedPrefix    lipgloss.Style
+	MatchHighlight      lipgloss.Style // Characters matched by the filter
+
+	// Tree select styles.
+	ExpandedNode  lipgloss.Style
+	CollapsedNode lipgloss.Style
+	PartialPrefix lipgloss.Style // Nodes with only some descendants selected
 
 	// Textinput and teatarea styles.
 	TextInput TextInputStyles
@@ -49,10 +65,54 @@ type FieldStyles struct {
 	FocusedButton lipgloss.Style
 	BlurredButton lipgloss.Style
 
+	// Date picker styles.
+	SelectedDate lipgloss.Style
+	Today        lipgloss.Style
+	DisabledDate lipgloss.Style
+	Weekday      lipgloss.Style
+
 	// Card styles.
 	Card      lipgloss.Style
 	NoteTitle lipgloss.Style
 	Next      lipgloss.Style
+
+	// Markdown styles of notes and descriptions.
+	Markdown MarkdownStyles
+}
+
+// MarkdownStyles are the styles for Markdown in notes and descriptions.
+type MarkdownStyles struct {
+	Heading       lipgloss.Style // First and second level headings
+	Subheading    lipgloss.Style // Third to sixth level headings
+	Emphasis      lipgloss.Style
+	Strong        lipgloss.Style
+	Strikethrough lipgloss.Style
+	Code          lipgloss.Style // Code spans
+	CodeBlock     lipgloss.Style // Lines of fenced code blocks
+	Link          lipgloss.Style
+	LinkURL       lipgloss.Style
+	BlockQuote    lipgloss.Style // Set with the marker of quoted lines
+	Bullet        lipgloss.Style // Set with the marker of list items
+	Rule          lipgloss.Style // Set with the character of thematic breaks
+	TableHeader   lipgloss.Style
+	TableBorder   lipgloss.Style
+}
+
+// ProgressStyles are the styles for the progress of a form through its
+// groups.
+type ProgressStyles struct {
+	Base    lipgloss.Style
+	Counter lipgloss.Style
+
+	// Bar styles, set with the character filling each cell of the bar.
+	BarFilled lipgloss.Style
+	BarEmpty  lipgloss.Style
+
+	// Breadcrumb styles.
+	Current   lipgloss.Style
+	Completed lipgloss.Style
+	Pending   lipgloss.Style
+	Separator lipgloss.Style
 }
 
 // TextInputStyles are the styles for text inputs.
@@ -76,6 +136,14 @@ func ThemeBase() *Theme {
 
 	t.FieldSeparator = lipgloss.NewStyle().SetString("\n\n")
 
+	// Progress styles.
+	t.Progress.Base = lipgloss.NewStyle().PaddingLeft(2).MarginBottom(1)
+	t.Progress.BarFilled = lipgloss.NewStyle().SetString("█")
+	t.Progress.BarEmpty = lipgloss.NewStyle().SetString("░")
+	t.Progress.Current = lipgloss.NewStyle().Bold(true)
+	t.Progress.Pending = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	t.Progress.Separator = lipgloss.NewStyle().SetString(" › ")
+
 	button := lipgloss.NewStyle().
 		Padding(buttonPaddingVertical, buttonPaddingHorizontal).
 		MarginRight(1)
@@ -86,14 +154,43 @@ func ThemeBase() *Theme {
 	t.Focused.ErrorIndicator = lipgloss.NewStyle().SetString(" *")
 	t.Focused.ErrorMessage = lipgloss.NewStyle().SetString(" *")
 	t.Focused.SelectSelector = lipgloss.NewStyle().SetString("> ")
+	t.Focused.OptionHeader = lipgloss.NewStyle().Bold(true)
+	t.Focused.OptionSeparator = lipgloss.NewStyle().Foreground(lipgloss.Color("8")).SetString("────────")
+	t.Focused.OptionDescription = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	t.Focused.DisabledOption = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	t.Focused.PreviewPane = lipgloss.NewStyle().Border(lipgloss.NormalBorder()).Padding(0, 1)
 	t.Focused.NextIndicator = lipgloss.NewStyle().MarginLeft(1).SetString("→")
 	t.Focused.PrevIndicator = lipgloss.NewStyle().MarginRight(1).SetString("←")
 	t.Focused.MultiSelectSelector = lipgloss.NewStyle().SetString("> ")
 	t.Focused.SelectedPrefix = lipgloss.NewStyle().SetString("[•] ")
 	t.Focused.UnselectedPrefix = lipgloss.NewStyle().SetString("[ ] ")
+	t.Focused.MatchHighlight = lipgloss.NewStyle().Underline(true)
+	t.Focused.ExpandedNode = lipgloss.NewStyle().SetString("▾ ")
+	t.Focused.CollapsedNode = lipgloss.NewStyle().SetString("▸ ")
+	t.Focused.PartialPrefix = lipgloss.NewStyle().SetString("[-] ")
 	t.Focused.FocusedButton = button.Foreground(lipgloss.Color("0")).Background(lipgloss.Color("7"))
 	t.Focused.BlurredButton = button.Foreground(lipgloss.Color("7")).Background(lipgloss.Color("0"))
 	t.Focused.TextInput.Placeholder = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	t.Focused.SelectedDate = lipgloss.NewStyle().Reverse(true)
+	t.Focused.Today = lipgloss.NewStyle().Underline(true)
+	t.Focused.DisabledDate = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	t.Focused.Weekday = lipgloss.NewStyle().Foreground(lipgloss.Color("8"))
+	t.Focused.Markdown = MarkdownStyles{
+		Heading:       lipgloss.NewStyle().Bold(true).Underline(true),
+		Subheading:    lipgloss.NewStyle().Bold(true),
+		Emphasis:      lipgloss.NewStyle().Italic(true),
+		Strong:        lipgloss.NewStyle().Bold(true),
+		Strikethrough: lipgloss.NewStyle().Strikethrough(true),
+		Code:          lipgloss.NewStyle().Foreground(lipgloss.Color("7")).Background(lipgloss.Color("0")).Padding(0, 1),
+		CodeBlock:     lipgloss.NewStyle().Foreground(lipgloss.Color("7")).PaddingLeft(2),
+		Link:          lipgloss.NewStyle().Underline(true),
+		LinkURL:       lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+		BlockQuote:    lipgloss.NewStyle().Foreground(lipgloss.Color("8")).SetString("│ "),
+		Bullet:        lipgloss.NewStyle().SetString("•"),
+		Rule:          lipgloss.NewStyle().Foreground(lipgloss.Color("8")).SetString("─"),
+		TableHeader:   lipgloss.NewStyle().Bold(true),
+		TableBorder:   lipgloss.NewStyle().Foreground(lipgloss.Color("8")),
+	}
 
 	t.Help = help.New().Styles
 
@@ -131,19 +228,50 @@ func ThemeCharm() *Theme {
 	t.Focused.NextIndicator = t.Focused.NextIndicator.Foreground(fuchsia)
 	t.Focused.PrevIndicator = t.Focused.PrevIndicator.Foreground(fuchsia)
 	t.Focused.Option = t.Focused.Option.Foreground(normalFg)
+	t.Focused.OptionHeader = t.Focused.OptionHeader.Foreground(indigo)
+	t.Focused.OptionSeparator = t.Focused.OptionSeparator.Foreground(lipgloss.AdaptiveColor{Light: "252", Dark: "238"})
+	t.Focused.OptionDescription = t.Focused.OptionDescription.Foreground(lipgloss.AdaptiveColor{Light: "", Dark: "243"})
+	t.Focused.DisabledOption = t.Focused.DisabledOption.Foreground(lipgloss.AdaptiveColor{Light: "248", Dark: "238"})
+	t.Focused.PreviewPane = t.Focused.PreviewPane.BorderForeground(lipgloss.AdaptiveColor{Light: "252", Dark: "238"})
 	t.Focused.MultiSelectSelector = t.Focused.MultiSelectSelector.Foreground(fuchsia)
 	t.Focused.SelectedOption = t.Focused.SelectedOption.Foreground(green)
 	t.Focused.SelectedPrefix = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#02CF92", Dark: "#02A877"}).SetString("✓ ")
 	t.Focused.UnselectedPrefix = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "", Dark: "243"}).SetString("• ")
 	t.Focused.UnselectedOption = t.Focused.UnselectedOption.Foreground(normalFg)
+	t.Focused.MatchHighlight = t.Focused.MatchHighlight.Foreground(fuchsia)
+	t.Focused.ExpandedNode = t.Focused.ExpandedNode.Foreground(lipgloss.AdaptiveColor{Light: "", Dark: "243"})
+	t.Focused.CollapsedNode = t.Focused.CollapsedNode.Foreground(lipgloss.AdaptiveColor{Light: "", Dark: "243"})
+	t.Focused.PartialPrefix = lipgloss.NewStyle().Foreground(lipgloss.AdaptiveColor{Light: "#02CF92", Dark: "#02A877"}).SetString("- ")
 	t.Focused.FocusedButton = t.Focused.FocusedButton.Foreground(cream).Background(fuchsia)
 	t.Focused.Next = t.Focused.FocusedButton
 	t.Focused.BlurredButton = t.Focused.BlurredButton.Foreground(normalFg).Background(lipgloss.AdaptiveColor{Light: "252", Dark: "237"})
+	t.Focused.SelectedDate = t.Focused.SelectedDate.UnsetReverse().Foreground(cream).Background(fuchsia)
+	t.Focused.Today = t.Focused.Today.Foreground(green)
+	t.Focused.DisabledDate = t.Focused.DisabledDate.Foreground(lipgloss.AdaptiveColor{Light: "248", Dark: "238"})
+	t.Focused.Weekday = t.Focused.Weekday.Foreground(lipgloss.AdaptiveColor{Light: "", Dark: "243"})
+	t.Focused.Markdown.Heading = t.Focused.Markdown.Heading.Foreground(indigo)
+	t.Focused.Markdown.Subheading = t.Focused.Markdown.Subheading.Foreground(indigo)
+	t.Focused.Markdown.Code = t.Focused.Markdown.Code.Foreground(fuchsia).Background(lipgloss.AdaptiveColor{Light: "254", Dark: "236"})
+	t.Focused.Markdown.CodeBlock = t.Focused.Markdown.CodeBlock.Foreground(normalFg)
+	t.Focused.Markdown.Link = t.Focused.Markdown.Link.Foreground(indigo)
+	t.Focused.Markdown.LinkURL = t.Focused.Markdown.LinkURL.Foreground(lipgloss.AdaptiveColor{Light: "", Dark: "243"})
+	t.Focused.Markdown.BlockQuote = t.Focused.Markdown.BlockQuote.Foreground(lipgloss.AdaptiveColor{Light: "252", Dark: "238"})
+	t.Focused.Markdown.Bullet = t.Focused.Markdown.Bullet.Foreground(fuchsia)
+	t.Focused.Markdown.Rule = t.Focused.Markdown.Rule.Foreground(lipgloss.AdaptiveColor{Light: "252", Dark: "238"})
+	t.Focused.Markdown.TableBorder = t.Focused.Markdown.TableBorder.Foreground(lipgloss.AdaptiveColor{Light: "252", Dark: "238"})
 
 	t.Focused.TextInput.Cursor = t.Focused.TextInput.Cursor.Foreground(green)
 	t.Focused.TextInput.Placeholder = t.Focused.TextInput.Placeholder.Foreground(lipgloss.AdaptiveColor{Light: "248", Dark: "238"})
 	t.Focused.TextInput.Prompt = t.Focused.TextInput.Prompt.Foreground(fuchsia)
 
+	t.Progress.Counter = t.Progress.Counter.Foreground(lipgloss.AdaptiveColor{Light: "", Dark: "243"})
+	t.Progress.BarFilled = t.Progress.BarFilled.Foreground(fuchsia)
+	t.Progress.BarEmpty = t.Progress.BarEmpty.Foreground(lipgloss.AdaptiveColor{Light: "252", Dark: "238"})
+	t.Progress.Current = t.Progress.Current.Foreground(indigo)
+	t.Progress.Completed = t.Progress.Completed.Foreground(green)
+	t.Progress.Pending = t.Progress.Pending.Foreground(lipgloss.AdaptiveColor{Light: "248", Dark: "243"})
+	t.Progress.Separator = t.Progress.Separator.Foreground(lipgloss.AdaptiveColor{Light: "248", Dark: "238"})
+
 	t.Blurred = t.Focused
 	t.Blurred.Base = t.Focused.Base.BorderStyle(lipgloss.HiddenBorder())
 	t.Blurred.NextIndicator = lipgloss.NewStyle()
@@ -179,18 +307,47 @@ func ThemeDracula() *Theme {
 	t.Focused.NextIndicator = t.Focused.NextIndicator.Foreground(yellow)
 	t.Focused.PrevIndicator = t.Focused.PrevIndicator.Foreground(yellow)
 	t.Focused.Option = t.Focused.Option.Foreground(foreground)
+	t.Focused.OptionHeader = t.Focused.OptionHeader.Foreground(purple)
+	t.Focused.OptionSeparator = t.Focused.OptionSeparator.Foreground(selection)
+	t.Focused.OptionDescription = t.Focused.OptionDescription.Foreground(comment)
+	t.Focused.DisabledOption = t.Focused.DisabledOption.Foreground(comment)
+	t.Focused.PreviewPane = t.Focused.PreviewPane.BorderForeground(selection)
 	t.Focused.MultiSelectSelector = t.Focused.MultiSelectSelector.Foreground(yellow)
 	t.Focused.SelectedOption = t.Focused.SelectedOption.Foreground(green)
 	t.Focused.SelectedPrefix = t.Focused.SelectedPrefix.Foreground(green)
 	t.Focused.UnselectedOption = t.Focused.UnselectedOption.Foreground(foreground)
 	t.Focused.UnselectedPrefix = t.Focused.UnselectedPrefix.Foreground(comment)
+	t.Focused.MatchHighlight = t.Focused.MatchHighlight.Foreground(yellow)
+	t.Focused.PartialPrefix = t.Focused.PartialPrefix.Foreground(green)
 	t.Focused.FocusedButton = t.Focused.FocusedButton.Foreground(yellow).Background(purple).Bold(true)
 	t.Focused.BlurredButton = t.Focused.BlurredButton.Foreground(foreground).Background(background)
+	t.Focused.SelectedDate = t.Focused.SelectedDate.UnsetReverse().Foreground(background).Background(purple)
+	t.Focused.Today = t.Focused.Today.Foreground(green)
+	t.Focused.DisabledDate = t.Focused.DisabledDate.Foreground(comment)
+	t.Focused.Weekday = t.Focused.Weekday.Foreground(comment)
+	t.Focused.Markdown.Heading = t.Focused.Markdown.Heading.Foreground(purple)
+	t.Focused.Markdown.Subheading = t.Focused.Markdown.Subheading.Foreground(purple)
+	t.Focused.Markdown.Code = t.Focused.Markdown.Code.Foreground(green).Background(selection)
+	t.Focused.Markdown.CodeBlock = t.Focused.Markdown.CodeBlock.Foreground(foreground)
+	t.Focused.Markdown.Link = t.Focused.Markdown.Link.Foreground(yellow)
+	t.Focused.Markdown.LinkURL = t.Focused.Markdown.LinkURL.Foreground(comment)
+	t.Focused.Markdown.BlockQuote = t.Focused.Markdown.BlockQuote.Foreground(comment)
+	t.Focused.Markdown.Bullet = t.Focused.Markdown.Bullet.Foreground(yellow)
+	t.Focused.Markdown.Rule = t.Focused.Markdown.Rule.Foreground(selection)
+	t.Focused.Markdown.TableBorder = t.Focused.Markdown.TableBorder.Foreground(selection)
 
 	t.Focused.TextInput.Cursor = t.Focused.TextInput.Cursor.Foreground(yellow)
 	t.Focused.TextInput.Placeholder = t.Focused.TextInput.Placeholder.Foreground(comment)
 	t.Focused.TextInput.Prompt = t.Focused.TextInput.Prompt.Foreground(yellow)
 
+	t.Progress.Counter = t.Progress.Counter.Foreground(comment)
+	t.Progress.BarFilled = t.Progress.BarFilled.Foreground(purple)
+	t.Progress.BarEmpty = t.Progress.BarEmpty.Foreground(selection)
+	t.Progress.Current = t.Progress.Current.Foreground(purple)
+	t.Progress.Completed = t.Progress.Completed.Foreground(green)
+	t.Progress.Pending = t.Progress.Pending.Foreground(comment)
+	t.Progress.Separator = t.Progress.Separator.Foreground(comment)
+
 	t.Blurred = t.Focused
 	t.Blurred.Base = t.Blurred.Base.BorderStyle(lipgloss.HiddenBorder())
 	t.Blurred.NextIndicator = lipgloss.NewStyle()
@@ -214,17 +371,34 @@ func ThemeBase16() *Theme {
 	t.Focused.NextIndicator = t.Focused.NextIndicator.Foreground(lipgloss.Color("3"))
 	t.Focused.PrevIndicator = t.Focused.PrevIndicator.Foreground(lipgloss.Color("3"))
 	t.Focused.Option = t.Focused.Option.Foreground(lipgloss.Color("7"))
+	t.Focused.OptionHeader = t.Focused.OptionHeader.Foreground(lipgloss.Color("6"))
+	t.Focused.PreviewPane = t.Focused.PreviewPane.BorderForeground(lipgloss.Color("8"))
+	t.Focused.Markdown.Heading = t.Focused.Markdown.Heading.Foreground(lipgloss.Color("6"))
+	t.Focused.Markdown.Subheading = t.Focused.Markdown.Subheading.Foreground(lipgloss.Color("6"))
+	t.Focused.Markdown.Link = t.Focused.Markdown.Link.Foreground(lipgloss.Color("4"))
+	t.Focused.Markdown.Bullet = t.Focused.Markdown.Bullet.Foreground(lipgloss.Color("3"))
 	t.Focused.MultiSelectSelector = t.Focused.MultiSelectSelector.Foreground(lipgloss.Color("3"))
 	t.Focused.SelectedOption = t.Focused.SelectedOption.Foreground(lipgloss.Color("2"))
 	t.Focused.SelectedPrefix = t.Focused.SelectedPrefix.Foreground(lipgloss.Color("2"))
 	t.Focused.UnselectedOption = t.Focused.UnselectedOption.Foreground(lipgloss.Color("7"))
+	t.Focused.MatchHighlight = t.Focused.MatchHighlight.Foreground(lipgloss.Color("3"))
+	t.Focused.PartialPrefix = t.Focused.PartialPrefix.Foreground(lipgloss.Color("2"))
 	t.Focused.FocusedButton = t.Focused.FocusedButton.Foreground(lipgloss.Color("7")).Background(lipgloss.Color("5"))
 	t.Focused.BlurredButton = t.Focused.BlurredButton.Foreground(lipgloss.Color("7")).Background(lipgloss.Color("0"))
+	t.Focused.SelectedDate = t.Focused.SelectedDate.UnsetReverse().Foreground(lipgloss.Color("7")).Background(lipgloss.Color("5"))
+	t.Focused.Today = t.Focused.Today.Foreground(lipgloss.Color("2"))
 
 	t.Focused.TextInput.Cursor.Foreground(lipgloss.Color("5"))
 	t.Focused.TextInput.Placeholder.Foreground(lipgloss.Color("8"))
 	t.Focused.TextInput.Prompt.Foreground(lipgloss.Color("3"))
 
+	t.Progress.Counter = t.Progress.Counter.Foreground(lipgloss.Color("8"))
+	t.Progress.BarFilled = t.Progress.BarFilled.Foreground(lipgloss.Color("5"))
+	t.Progress.BarEmpty = t.Progress.BarEmpty.Foreground(lipgloss.Color("8"))
+	t.Progress.Current = t.Progress.Current.Foreground(lipgloss.Color("6"))
+	t.Progress.Completed = t.Progress.Completed.Foreground(lipgloss.Color("2"))
+	t.Progress.Separator = t.Progress.Separator.Foreground(lipgloss.Color("8"))
+
 	t.Blurred = t.Focused
 	t.Blurred.Base = t.Blurred.Base.BorderStyle(lipgloss.HiddenBorder())
 	t.Blurred.NoteTitle = t.Blurred.NoteTitle.Foreground(lipgloss.Color("8"))
@@ -270,18 +444,47 @@ func ThemeCatppuccin() *Theme {
 	t.Focused.NextIndicator = t.Focused.NextIndicator.Foreground(pink)
 	t.Focused.PrevIndicator = t.Focused.PrevIndicator.Foreground(pink)
 	t.Focused.Option = t.Focused.Option.Foreground(text)
+	t.Focused.OptionHeader = t.Focused.OptionHeader.Foreground(mauve)
+	t.Focused.OptionSeparator = t.Focused.OptionSeparator.Foreground(overlay0)
+	t.Focused.OptionDescription = t.Focused.OptionDescription.Foreground(subtext0)
+	t.Focused.DisabledOption = t.Focused.DisabledOption.Foreground(overlay0)
+	t.Focused.PreviewPane = t.Focused.PreviewPane.BorderForeground(overlay0)
 	t.Focused.MultiSelectSelector = t.Focused.MultiSelectSelector.Foreground(pink)
 	t.Focused.SelectedOption = t.Focused.SelectedOption.Foreground(green)
 	t.Focused.SelectedPrefix = t.Focused.SelectedPrefix.Foreground(green)
 	t.Focused.UnselectedPrefix = t.Focused.UnselectedPrefix.Foreground(text)
 	t.Focused.UnselectedOption = t.Focused.UnselectedOption.Foreground(text)
+	t.Focused.MatchHighlight = t.Focused.MatchHighlight.Foreground(pink)
+	t.Focused.PartialPrefix = t.Focused.PartialPrefix.Foreground(green)
 	t.Focused.FocusedButton = t.Focused.FocusedButton.Foreground(base).Background(pink)
 	t.Focused.BlurredButton = t.Focused.BlurredButton.Foreground(text).Background(base)
+	t.Focused.SelectedDate = t.Focused.SelectedDate.UnsetReverse().Foreground(base).Background(pink)
+	t.Focused.Today = t.Focused.Today.Foreground(green)
+	t.Focused.DisabledDate = t.Focused.DisabledDate.Foreground(overlay0)
+	t.Focused.Weekday = t.Focused.Weekday.Foreground(subtext0)
+	t.Focused.Markdown.Heading = t.Focused.Markdown.Heading.Foreground(mauve)
+	t.Focused.Markdown.Subheading = t.Focused.Markdown.Subheading.Foreground(mauve)
+	t.Focused.Markdown.Code = t.Focused.Markdown.Code.Foreground(pink).Background(base)
+	t.Focused.Markdown.CodeBlock = t.Focused.Markdown.CodeBlock.Foreground(text)
+	t.Focused.Markdown.Link = t.Focused.Markdown.Link.Foreground(pink)
+	t.Focused.Markdown.LinkURL = t.Focused.Markdown.LinkURL.Foreground(subtext0)
+	t.Focused.Markdown.BlockQuote = t.Focused.Markdown.BlockQuote.Foreground(overlay0)
+	t.Focused.Markdown.Bullet = t.Focused.Markdown.Bullet.Foreground(pink)
+	t.Focused.Markdown.Rule = t.Focused.Markdown.Rule.Foreground(overlay0)
+	t.Focused.Markdown.TableBorder = t.Focused.Markdown.TableBorder.Foreground(overlay0)
 
 	t.Focused.TextInput.Cursor = t.Focused.TextInput.Cursor.Foreground(cursor)
 	t.Focused.TextInput.Placeholder = t.Focused.TextInput.Placeholder.Foreground(overlay0)
 	t.Focused.TextInput.Prompt = t.Focused.TextInput.Prompt.Foreground(pink)
 
+	t.Progress.Counter = t.Progress.Counter.Foreground(subtext0)
+	t.Progress.BarFilled = t.Progress.BarFilled.Foreground(pink)
+	t.Progress.BarEmpty = t.Progress.BarEmpty.Foreground(overlay0)
+	t.Progress.Current = t.Progress.Current.Foreground(mauve)
+	t.Progress.Completed = t.Progress.Completed.Foreground(green)
+	t.Progress.Pending = t.Progress.Pending.Foreground(overlay1)
+	t.Progress.Separator = t.Progress.Separator.Foreground(overlay0)
+
 	t.Blurred = t.Focused
 	t.Blurred.Base = t.Blurred.Base.BorderStyle(lipgloss.HiddenBorder())
 
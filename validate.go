@@ -1,7 +1,10 @@
 package huh
 
 import (
+	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -59,3 +62,42 @@ func ValidateOneOf(options ...string) func(string) error {
 		return nil
 	}
 }
+
+// validatorFromSpec returns a validation function from a pipe separated list
+// of validator names, such as "notempty|minlen:3|maxlen:20".
+func validatorFromSpec(spec string) (func(string) error, error) {
+	var validators []func(string) error
+	for _, part := range strings.Split(spec, "|") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(part), ":")
+		switch name {
+		case "":
+			continue
+		case "notempty":
+			validators = append(validators, ValidateNotEmpty())
+		case "minlen", "maxlen":
+			n, err := strconv.Atoi(arg)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s length %q", name, arg)
+			}
+			if name == "minlen" {
+				validators = append(validators, ValidateMinLength(n))
+			} else {
+				validators = append(validators, ValidateMaxLength(n))
+			}
+		default:
+			return nil, fmt.Errorf("unknown validator %q", name)
+		}
+	}
+	if len(validators) == 0 {
+		return nil, errors.New("no validators given")
+	}
+
+	return func(s string) error {
+		for _, validate := range validators {
+			if err := validate(s); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, nil
+}
@@ -0,0 +1,191 @@
+package huh
+
+import (
+	"context"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// asyncValidateDebounce is how long asynchronous validation waits for the
+// value to settle, such as the user to stop typing, before running.
+const asyncValidateDebounce = 300 * time.Millisecond
+
+// asyncValidation runs a context-aware validation function as a command.
+//
+// Validation is scheduled every time the value of the field changes and runs
+// once the value has settled. Scheduling a new validation cancels the context
+// of the running one and discards its result. While a validation is pending
+// the field must not move on.
+type asyncValidation[T any] struct {
+	id int
+	fn func(context.Context, T) error
+
+	seq     int
+	value   T
+	cancel  context.CancelFunc
+	pending bool
+	running bool
+	start   time.Time
+	err     error
+
+	spinner spinner.Model
+}
+
+// asyncValidateMsg is sent when the value to validate has settled.
+type asyncValidateMsg struct {
+	id  int
+	seq int
+}
+
+// asyncValidatedMsg is sent with the result of an asynchronous validation.
+type asyncValidatedMsg struct {
+	id  int
+	seq int
+	err error
+}
+
+// newAsyncValidation returns an asynchronous validation for the field with the
+// given id.
+func newAsyncValidation[T any](id int, fn func(context.Context, T) error) *asyncValidation[T] {
+	if fn == nil {
+		return nil
+	}
+	return &asyncValidation[T]{
+		id:      id,
+		fn:      fn,
+		spinner: spinner.New(spinner.WithSpinner(spinner.Line)),
+	}
+}
+
+// schedule schedules the validation of the given value, cancelling any stale
+// validation.
+func (v *asyncValidation[T]) schedule(value T) tea.Cmd {
+	if v == nil {
+		return nil
+	}
+	v.stop()
+	v.seq++
+	v.value = value
+	v.pending = true
+	v.err = nil
+
+	id, seq := v.id, v.seq
+	return tea.Tick(asyncValidateDebounce, func(time.Time) tea.Msg {
+		return asyncValidateMsg{id: id, seq: seq}
+	})
+}
+
+// init schedules the validation of the initial value of the field, unless a
+// validation was already scheduled.
+func (v *asyncValidation[T]) init(value T) tea.Cmd {
+	if v == nil || v.seq > 0 {
+		return nil
+	}
+	return v.schedule(value)
+}
+
+// reset cancels any pending validation and clears its result, for instance
+// when the value is rejected by the synchronous validation.
+func (v *asyncValidation[T]) reset() {
+	if v == nil {
+		return
+	}
+	v.stop()
+	v.seq++
+	v.pending = false
+	v.err = nil
+}
+
+// stop cancels the running validation, if any.
+func (v *asyncValidation[T]) stop() {
+	if v.cancel != nil {
+		v.cancel()
+		v.cancel = nil
+	}
+	v.running = false
+}
+
+// update handles the messages of the asynchronous validation.
+func (v *asyncValidation[T]) update(msg tea.Msg) tea.Cmd {
+	if v == nil {
+		return nil
+	}
+
+	switch msg := msg.(type) {
+	case asyncValidateMsg:
+		if msg.id != v.id || msg.seq != v.seq || !v.pending || v.running {
+			break
+		}
+		ctx, cancel := context.WithCancel(context.Background())
+		v.cancel = cancel
+		v.running = true
+		v.start = time.Now()
+
+		fn, value := v.fn, v.value
+		return tea.Batch(func() tea.Msg {
+			return asyncValidatedMsg{id: msg.id, seq: msg.seq, err: fn(ctx, value)}
+		}, v.spinner.Tick)
+
+	case asyncValidatedMsg:
+		if msg.id != v.id || msg.seq != v.seq {
+			break
+		}
+		v.stop()
+		v.pending = false
+		v.err = msg.err
+
+	case spinner.TickMsg:
+		if !v.running {
+			break
+		}
+		var cmd tea.Cmd
+		v.spinner, cmd = v.spinner.Update(msg)
+		return cmd
+	}
+
+	return nil
+}
+
+// blocked returns whether the field can't move on, either because the
+// validation is pending or because it failed.
+func (v *asyncValidation[T]) blocked() bool {
+	return v != nil && (v.pending || v.err != nil)
+}
+
+// error returns the error of the last resolved validation.
+func (v *asyncValidation[T]) error() error {
+	if v == nil || v.pending {
+		return nil
+	}
+	return v.err
+}
+
+// run runs the validation synchronously, for accessible mode.
+func (v *asyncValidation[T]) run(value T) error {
+	if v == nil {
+		return nil
+	}
+	return v.fn(context.Background(), value)
+}
+
+// view renders the loading indicator of a running validation.
+func (v *asyncValidation[T]) view(styles *FieldStyles) string {
+	if v == nil || !v.running || time.Since(v.start) <= spinnerShowThreshold {
+		return ""
+	}
+	v.spinner.Style = styles.MultiSelectSelector.UnsetString()
+	return " " + v.spinner.View() + styles.Description.Render(" Validating...")
+}
+
+// withAsyncValidation combines a synchronous validation function with an
+// asynchronous one run synchronously, for accessible mode.
+func withAsyncValidation[T any](validate func(T) error, v *asyncValidation[T]) func(T) error {
+	return func(value T) error {
+		if err := validate(value); err != nil {
+			return err
+		}
+		return v.run(value)
+	}
+}